@@ -2,6 +2,7 @@ package bus
 
 import (
 	"context"
+	"time"
 
 	"tracking-server/shared"
 	"tracking-server/shared/dto"
@@ -20,6 +21,7 @@ type (
 		FindAllBus(bus *[]dto.Bus) error
 		FindBusLatestLocation(id uint, location *dto.BusLocation) error
 		InsertBusLocationFirebase(location *map[string]interface{}, client *firestore.Client, firebaseCtx context.Context) error
+		ListenBusLocationFirebase(since time.Time, client *firestore.Client, firebaseCtx context.Context) *firestore.QuerySnapshotIterator
 	}
 	service struct {
 		shared shared.Holder
@@ -76,6 +78,14 @@ func (s *service) InsertBusLocationFirebase(location *map[string]interface{}, cl
 	return err
 }
 
+func (s *service) ListenBusLocationFirebase(since time.Time, client *firestore.Client, firebaseCtx context.Context) *firestore.QuerySnapshotIterator {
+	return client.Collection("bus_locations").
+		Where("timestamp", ">=", since).
+		OrderBy("timestamp", firestore.Desc).
+		Limit(dto.FIREBASESTREAMLIMIT).
+		Snapshots(firebaseCtx)
+}
+
 func NewBusService(shared shared.Holder) Service {
 	return &service{
 		shared: shared,
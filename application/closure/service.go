@@ -0,0 +1,138 @@
+package closure
+
+import (
+	"sync"
+	"time"
+
+	"tracking-server/shared"
+	"tracking-server/shared/dto"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type (
+	Service interface {
+		Create(data *dto.Closure) error
+		FindAll(query dto.GetAllClosureQuery, data *[]dto.Closure) error
+		FindById(id string, data *dto.Closure) error
+		Save(data *dto.Closure, terminals *[]dto.Terminal) error
+		Delete(data *dto.Closure) error
+		GetActive(route dto.Route, at time.Time) dto.ClosureSlice
+	}
+	service struct {
+		shared   shared.Holder
+		mu       sync.Mutex
+		loadedAt time.Time
+		closures []dto.Closure
+	}
+)
+
+/**
+ * Create closure along with its closed terminal, terminal itself not updated
+ */
+func (s *service) Create(data *dto.Closure) error {
+	err := s.shared.DB.Omit("Terminals.*").Create(data).Error
+	s.invalidate()
+	return err
+}
+
+/**
+ * Find closure ordered by start time
+ * * if active is set, closure already ended not included
+ */
+func (s *service) FindAll(query dto.GetAllClosureQuery, data *[]dto.Closure) error {
+	db := s.shared.DB.Preload("Terminals")
+
+	if query.Route != "" {
+		db = db.Where("route = ?", query.Route)
+	}
+
+	if query.Active {
+		db = db.Where("end_at > ?", time.Now())
+	}
+
+	err := db.Order("start_at, id").Find(data).Error
+	return err
+}
+
+func (s *service) FindById(id string, data *dto.Closure) error {
+	err := s.shared.DB.Preload("Terminals").Where("id = ?", id).First(data).Error
+	return err
+}
+
+/**
+ * Save closure and replace its closed terminal in one transaction
+ * * if terminals is nil, closed terminal kept as is
+ */
+func (s *service) Save(data *dto.Closure, terminals *[]dto.Terminal) error {
+	err := s.shared.DB.Transaction(func(tx *gorm.DB) error {
+		err := tx.Omit(clause.Associations).Save(data).Error
+		if err != nil || terminals == nil {
+			return err
+		}
+
+		return tx.Model(data).Association("Terminals").Replace(*terminals)
+	})
+	s.invalidate()
+	return err
+}
+
+func (s *service) Delete(data *dto.Closure) error {
+	err := s.shared.DB.Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(data).Association("Terminals").Clear()
+		if err != nil {
+			return err
+		}
+
+		return tx.Delete(&dto.Closure{}, data.ID).Error
+	})
+	s.invalidate()
+	return err
+}
+
+/**
+ * Closure of the route in effect at the given time
+ * Closure not ended yet kept in memory, reloaded after every change or when expired
+ */
+func (s *service) GetActive(route dto.Route, at time.Time) dto.ClosureSlice {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.load()
+
+	res := make(dto.ClosureSlice, 0)
+	for _, c := range s.closures {
+		if c.Route == route && c.InEffect(at) {
+			res = append(res, c)
+		}
+	}
+	return res
+}
+
+func (s *service) load() {
+	if !s.loadedAt.IsZero() && time.Since(s.loadedAt) < dto.CLOSURECACHETTL {
+		return
+	}
+
+	closures := []dto.Closure{}
+	if err := s.FindAll(dto.GetAllClosureQuery{Active: true}, &closures); err != nil {
+		s.shared.Logger.Errorf("error when finding active closure, err: %s", err.Error())
+		return
+	}
+
+	s.closures = closures
+	s.loadedAt = time.Now()
+}
+
+func (s *service) invalidate() {
+	s.mu.Lock()
+	s.loadedAt = time.Time{}
+	s.mu.Unlock()
+}
+
+func NewClosureService(shared shared.Holder) Service {
+	return &service{
+		shared: shared,
+	}
+}
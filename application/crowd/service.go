@@ -0,0 +1,48 @@
+package crowd
+
+import (
+	"time"
+
+	"tracking-server/shared"
+	"tracking-server/shared/dto"
+)
+
+type (
+	Service interface {
+		Create(data *dto.CrowdStatusChange) error
+		FindSince(route dto.Route, since time.Time, data *[]dto.CrowdStatusChange) error
+		FindByTerminal(terminalID uint, since time.Time, data *[]dto.CrowdStatusChange) error
+	}
+	service struct {
+		shared shared.Holder
+	}
+)
+
+func (s *service) Create(data *dto.CrowdStatusChange) error {
+	err := s.shared.DB.Create(data).Error
+	return err
+}
+
+/**
+ * Find status change since the time ordered per bus, empty route find every route
+ */
+func (s *service) FindSince(route dto.Route, since time.Time, data *[]dto.CrowdStatusChange) error {
+	db := s.shared.DB.Where("timestamp >= ?", since)
+	if route != "" {
+		db = db.Where("route = ?", route)
+	}
+
+	err := db.Order("bus_id, timestamp").Find(data).Error
+	return err
+}
+
+func (s *service) FindByTerminal(terminalID uint, since time.Time, data *[]dto.CrowdStatusChange) error {
+	err := s.shared.DB.Where("terminal_id = ? AND timestamp >= ?", terminalID, since).Find(data).Error
+	return err
+}
+
+func NewCrowdService(shared shared.Holder) Service {
+	return &service{
+		shared: shared,
+	}
+}
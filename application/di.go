@@ -2,9 +2,26 @@ package application
 
 import (
 	"tracking-server/application/bus"
+	"tracking-server/application/closure"
+	"tracking-server/application/crowd"
+	"tracking-server/application/geofence"
+	"tracking-server/application/headway"
 	"tracking-server/application/healthcheck"
+	"tracking-server/application/network"
 	"tracking-server/application/news"
+	"tracking-server/application/place"
+	"tracking-server/application/progress"
+	"tracking-server/application/report"
+	"tracking-server/application/route"
+	"tracking-server/application/sandbox"
+	"tracking-server/application/schedule"
+	"tracking-server/application/search"
+	"tracking-server/application/shape"
+	"tracking-server/application/simulator"
+	"tracking-server/application/station"
+	"tracking-server/application/subscription"
 	"tracking-server/application/terminal"
+	"tracking-server/application/traveltime"
 
 	"github.com/pkg/errors"
 	"go.uber.org/dig"
@@ -12,10 +29,27 @@ import (
 
 type Holder struct {
 	dig.In
-	HealthcheckService healthcheck.Service
-	BusService         bus.Service
-	NewsService        news.Service
-	TerminalService    terminal.Service
+	HealthcheckService  healthcheck.Service
+	BusService          bus.Service
+	NewsService         news.Service
+	TerminalService     terminal.Service
+	StationService      station.Service
+	RouteService        route.Service
+	ShapeService        shape.Service
+	ClosureService      closure.Service
+	PlaceService        place.Service
+	SearchService       search.Service
+	NetworkService      network.Service
+	SimulatorService    simulator.Service
+	SandboxService      sandbox.Service
+	ProgressService     progress.Service
+	TravelTimeService   traveltime.Service
+	GeofenceService     geofence.Service
+	HeadwayService      headway.Service
+	SubscriptionService subscription.Service
+	ScheduleService     schedule.Service
+	CrowdService        crowd.Service
+	ReportService       report.Service
 }
 
 func Register(container *dig.Container) error {
@@ -31,9 +65,96 @@ func Register(container *dig.Container) error {
 		return errors.Wrap(err, "failed to provide news service")
 	}
 
+	if err := container.Provide(route.NewRouteService); err != nil {
+		return errors.Wrap(err, "failed to provide route service")
+	}
+
+	if err := container.Provide(station.NewStationService); err != nil {
+		return errors.Wrap(err, "failed to provide station service")
+	}
+
+	if err := container.Provide(closure.NewClosureService); err != nil {
+		return errors.Wrap(err, "failed to provide closure service")
+	}
+
+	if err := container.Provide(shape.NewShapeService); err != nil {
+		return errors.Wrap(err, "failed to provide shape service")
+	}
+
 	if err := container.Provide(terminal.NewTerminalService); err != nil {
 		return errors.Wrap(err, "failed to provide terminal service")
 	}
 
+	if err := container.Provide(place.NewPlaceService); err != nil {
+		return errors.Wrap(err, "failed to provide place service")
+	}
+
+	if err := container.Provide(search.NewSearchService); err != nil {
+		return errors.Wrap(err, "failed to provide search service")
+	}
+
+	if err := container.Provide(network.NewNetworkService); err != nil {
+		return errors.Wrap(err, "failed to provide network service")
+	}
+
+	if err := container.Provide(progress.NewProgressService); err != nil {
+		return errors.Wrap(err, "failed to provide progress service")
+	}
+
+	if err := container.Provide(simulator.NewSimulatorService); err != nil {
+		return errors.Wrap(err, "failed to provide simulator service")
+	}
+
+	if err := container.Provide(sandbox.NewSandboxService); err != nil {
+		return errors.Wrap(err, "failed to provide sandbox service")
+	}
+
+	if err := container.Provide(traveltime.NewTravelTimeService); err != nil {
+		return errors.Wrap(err, "failed to provide travel time service")
+	}
+
+	if err := container.Provide(geofence.NewGeofenceService); err != nil {
+		return errors.Wrap(err, "failed to provide geofence service")
+	}
+
+	if err := container.Provide(headway.NewHeadwayService); err != nil {
+		return errors.Wrap(err, "failed to provide headway service")
+	}
+
+	if err := container.Provide(subscription.NewSubscriptionService); err != nil {
+		return errors.Wrap(err, "failed to provide subscription service")
+	}
+
+	if err := container.Provide(schedule.NewScheduleService); err != nil {
+		return errors.Wrap(err, "failed to provide schedule service")
+	}
+
+	if err := container.Provide(crowd.NewCrowdService); err != nil {
+		return errors.Wrap(err, "failed to provide crowd service")
+	}
+
+	if err := container.Provide(report.NewReportService); err != nil {
+		return errors.Wrap(err, "failed to provide report service")
+	}
+
 	return nil
 }
+
+/**
+ * Seed route network from data file and group terminal into station before worker read it
+ * Rider report rate limit and crowd signal rebuilt from stored report
+ */
+func Seed(holder Holder) {
+	holder.NetworkService.Seed()
+	holder.StationService.Seed()
+	holder.ReportService.Seed()
+}
+
+/**
+ * Start background worker for each module
+ */
+func Workers(holder Holder) {
+	go holder.SimulatorService.Run()
+	go holder.TravelTimeService.Run()
+	go holder.HeadwayService.Run()
+}
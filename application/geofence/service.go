@@ -0,0 +1,121 @@
+package geofence
+
+import (
+	"sync"
+	"time"
+
+	"tracking-server/shared"
+	"tracking-server/shared/dto"
+)
+
+type (
+	Service interface {
+		Process(busID uint, route dto.Route, lat float64, long float64, at time.Time, terminals []dto.Terminal) []dto.TerminalEvent
+		Create(data *[]dto.TerminalEvent) error
+		FindAll(query dto.TerminalEventQuery, data *[]dto.TerminalEvent) error
+	}
+	service struct {
+		shared shared.Holder
+		mu     sync.Mutex
+		state  map[uint]dto.GeofenceState
+	}
+)
+
+/**
+ * Detect arrival and departure of a bus from the terminal geofence
+ * Bus leave the geofence only after moving past a wider radius so gps noise
+ * around the border does not produce duplicate event
+ */
+func (s *service) Process(busID uint, route dto.Route, lat float64, long float64, at time.Time, terminals []dto.Terminal) []dto.TerminalEvent {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var (
+		radius      = dto.GeofenceRadius(s.shared.Env)
+		path        = dto.TerminalSlice(terminals).ToRoute()
+		events      = make([]dto.TerminalEvent, 0)
+		current, ok = s.state[busID]
+	)
+
+	if ok {
+		exit := radius * dto.GEOFENCEEXITFACTOR
+		index := dto.TerminalSlice(terminals).Index(current.TerminalID)
+		if index >= 0 && current.Route == route && path.DistanceToStop(lat, long, index) <= exit {
+			return events
+		}
+
+		events = append(events, dto.TerminalEvent{
+			BusID:      busID,
+			TerminalID: current.TerminalID,
+			Route:      current.Route,
+			Type:       dto.DEPARTURE,
+			Timestamp:  at,
+			Dwell:      at.Sub(current.EnteredAt).Seconds(),
+		})
+		delete(s.state, busID)
+	}
+
+	index := path.NearestStop(lat, long, radius, nil)
+	if index < 0 {
+		return events
+	}
+	terminal := terminals[index]
+
+	s.state[busID] = dto.GeofenceState{
+		TerminalID: terminal.ID,
+		Route:      route,
+		EnteredAt:  at,
+	}
+	events = append(events, dto.TerminalEvent{
+		BusID:      busID,
+		TerminalID: terminal.ID,
+		Route:      route,
+		Type:       dto.ARRIVAL,
+		Timestamp:  at,
+	})
+
+	return events
+}
+
+func (s *service) Create(data *[]dto.TerminalEvent) error {
+	err := s.shared.DB.Create(data).Error
+	return err
+}
+
+func (s *service) FindAll(query dto.TerminalEventQuery, data *[]dto.TerminalEvent) error {
+	db := s.shared.DB.Order("timestamp desc")
+
+	if query.BusID != 0 {
+		db = db.Where("bus_id = ?", query.BusID)
+	}
+	if query.TerminalID != 0 {
+		db = db.Where("terminal_id = ?", query.TerminalID)
+	}
+	if query.Route != "" {
+		db = db.Where("route = ?", query.Route)
+	}
+	if query.Type != "" {
+		db = db.Where("type = ?", query.Type)
+	}
+	if from, err := time.Parse(time.RFC3339, query.From); err == nil {
+		db = db.Where("timestamp >= ?", from)
+	}
+	if to, err := time.Parse(time.RFC3339, query.To); err == nil {
+		db = db.Where("timestamp <= ?", to)
+	}
+
+	limit := query.Limit
+	if limit <= 0 {
+		limit = dto.DEFAULTEVENTLIMIT
+	}
+
+	err := db.Limit(limit).Find(data).Error
+	return err
+}
+
+func NewGeofenceService(shared shared.Holder) Service {
+	return &service{
+		shared: shared,
+		state:  make(map[uint]dto.GeofenceState),
+	}
+}
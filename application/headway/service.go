@@ -0,0 +1,320 @@
+package headway
+
+import (
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"tracking-server/application/bus"
+	"tracking-server/application/progress"
+	"tracking-server/application/route"
+	"tracking-server/application/simulator"
+	"tracking-server/application/terminal"
+	"tracking-server/application/traveltime"
+	"tracking-server/shared"
+	"tracking-server/shared/common"
+	"tracking-server/shared/dto"
+)
+
+type (
+	Service interface {
+		Run()
+		Compute() ([]dto.RouteHeadway, error)
+		GetHeadway() []dto.RouteHeadway
+		GetActiveAlert() []dto.HeadwayAlert
+		Subscribe() (<-chan dto.HeadwayAlert, func())
+	}
+	service struct {
+		shared      shared.Holder
+		bus         bus.Service
+		simulator   simulator.Service
+		progress    progress.Service
+		route       route.Service
+		terminal    terminal.Service
+		traveltime  traveltime.Service
+		mu          sync.RWMutex
+		headways    []dto.RouteHeadway
+		status      map[uint]dto.HeadwayStatus
+		subscribers map[int]chan dto.HeadwayAlert
+		nextID      int
+	}
+
+	// position of a bus in second and km from the first terminal of the route
+	position struct {
+		bus      dto.TrackLocationResponse
+		seconds  float64
+		distance float64
+	}
+)
+
+/**
+ * Compute headway periodically and publish alert on status change
+ * Monitoring disabled when interval is not set
+ */
+func (s *service) Run() {
+	interval := time.Duration(s.shared.Env.HeadwayInterval) * time.Second
+	if interval <= 0 {
+		return
+	}
+
+	for {
+		if _, err := s.Compute(); err != nil {
+			s.shared.Logger.Errorf("error when computing headway, err: %s", err.Error())
+		}
+		time.Sleep(interval)
+	}
+}
+
+/**
+ * Compute headway between consecutive active bus of every route
+ * Headway is the expected time for a bus to reach the current position of the bus ahead
+ */
+func (s *service) Compute() ([]dto.RouteHeadway, error) {
+	var (
+		now    = time.Now()
+		buses  = []dto.Bus{}
+		active = make(map[uint]dto.TrackLocationResponse)
+		routes = s.route.GetActive()
+		result = make([]dto.RouteHeadway, 0, len(routes))
+	)
+
+	if err := s.bus.FindAllBus(&buses); err != nil {
+		return nil, err
+	}
+	for _, b := range buses {
+		if b.IsActive {
+			active[b.ID] = b.ToTrackLocationResponse()
+		}
+	}
+	for _, b := range s.simulator.GetFleet() {
+		active[b.ID] = b
+	}
+
+	progress := s.progress.GetAll()
+
+	for _, route := range routes {
+		terminals := []dto.Terminal{}
+		if err := s.terminal.GetAllByRoute(route, &terminals); err != nil {
+			return nil, err
+		}
+		if len(terminals) < 2 {
+			continue
+		}
+
+		path := dto.TerminalSlice(terminals).ToRoute()
+		cumulative, lap := s.cumulativeTime(terminals, path, now)
+		if lap <= 0 {
+			continue
+		}
+
+		distances := make([]float64, len(terminals))
+		for i := 1; i < len(terminals); i++ {
+			distances[i] = distances[i-1] + path.Segments[i-1]
+		}
+
+		positions := make([]position, 0)
+		for _, p := range progress {
+			b, ok := active[p.BusID]
+			if !ok || p.Route != route || b.Route != route || p.Segment >= len(path.Points) {
+				continue
+			}
+
+			ratio := 0.0
+			if path.Segments[p.Segment] > 0 {
+				ratio = p.Offset / path.Segments[p.Segment]
+			}
+			positions = append(positions, position{
+				bus:      b,
+				seconds:  cumulative[p.Segment] + (cumulative[p.Segment+1]-cumulative[p.Segment])*ratio,
+				distance: distances[p.Segment] + p.Offset,
+			})
+		}
+
+		result = append(result, dto.RouteHeadway{
+			Route:     route,
+			Buses:     len(positions),
+			Headways:  s.headway(positions, lap, path.Length),
+			UpdatedAt: now,
+		})
+	}
+
+	s.publish(result, now)
+
+	return result, nil
+}
+
+func (s *service) GetHeadway() []dto.RouteHeadway {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return append([]dto.RouteHeadway{}, s.headways...)
+}
+
+/**
+ * Get alert of every bus currently bunching or in a gap
+ */
+func (s *service) GetActiveAlert() []dto.HeadwayAlert {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	alerts := make([]dto.HeadwayAlert, 0)
+	for _, r := range s.headways {
+		for _, h := range r.Headways {
+			if h.Status != dto.NORMAL {
+				alerts = append(alerts, h.ToHeadwayAlert(r.Route, h.Status, r.UpdatedAt))
+			}
+		}
+	}
+	return alerts
+}
+
+/**
+ * Subscribe to headway alert, call the returned function to unsubscribe
+ */
+func (s *service) Subscribe() (<-chan dto.HeadwayAlert, func()) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := s.nextID
+	s.nextID++
+
+	ch := make(chan dto.HeadwayAlert, dto.HEADWAYALERTBUFFER)
+	s.subscribers[id] = ch
+
+	return ch, func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		if _, ok := s.subscribers[id]; ok {
+			delete(s.subscribers, id)
+			close(ch)
+		}
+	}
+}
+
+/**
+ * Expected second from the first terminal to each terminal, last element is the full lap
+ * Use historical segment travel time, fallback to nominal speed
+ */
+func (s *service) cumulativeTime(terminals []dto.Terminal, path common.Route, at time.Time) ([]float64, float64) {
+	cumulative := make([]float64, len(terminals)+1)
+
+	for i := range terminals {
+		seconds, ok := s.traveltime.SegmentTime(terminals[i].ID, terminals[path.Next(i)].ID, at)
+		if !ok {
+			seconds = path.Segments[i] * 1000 / dto.NOMINALBUSSPEED
+		}
+		cumulative[i+1] = cumulative[i] + seconds
+	}
+
+	return cumulative, cumulative[len(terminals)]
+}
+
+/**
+ * Pair every bus with the bus ahead of it, the leading bus follow the last one on the next lap
+ */
+func (s *service) headway(positions []position, lap float64, length float64) []dto.Headway {
+	res := make([]dto.Headway, 0, len(positions))
+	if len(positions) < 2 {
+		return res
+	}
+
+	sort.Slice(positions, func(i, j int) bool {
+		return positions[i].seconds < positions[j].seconds
+	})
+
+	for i, p := range positions {
+		leader := positions[(i+1)%len(positions)]
+
+		h := dto.Headway{
+			BusID:        p.bus.ID,
+			Number:       p.bus.Number,
+			Plate:        p.bus.Plate,
+			LeaderID:     leader.bus.ID,
+			LeaderNumber: leader.bus.Number,
+			Distance:     math.Round(math.Mod(leader.distance-p.distance+length, length)*100) / 100,
+			Minute:       math.Round(math.Mod(leader.seconds-p.seconds+lap, lap)/60*10) / 10,
+			Status:       dto.NORMAL,
+		}
+
+		switch {
+		case h.Minute < s.shared.Env.HeadwayMin:
+			h.Status = dto.BUNCHING
+		case s.shared.Env.HeadwayMax > 0 && h.Minute > s.shared.Env.HeadwayMax:
+			h.Status = dto.GAP
+		}
+
+		res = append(res, h)
+	}
+
+	return res
+}
+
+/**
+ * Store the latest headway and notify subscriber when a bus status changes
+ */
+func (s *service) publish(headways []dto.RouteHeadway, at time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var (
+		alerts = make([]dto.HeadwayAlert, 0)
+		status = make(map[uint]dto.HeadwayStatus)
+	)
+
+	for _, r := range headways {
+		for _, h := range r.Headways {
+			status[h.BusID] = h.Status
+
+			previous, ok := s.status[h.BusID]
+			if !ok {
+				previous = dto.NORMAL
+			}
+
+			switch {
+			case h.Status == previous:
+			case h.Status == dto.NORMAL:
+				alerts = append(alerts, h.ToHeadwayAlert(r.Route, dto.RESOLVED, at))
+			default:
+				alerts = append(alerts, h.ToHeadwayAlert(r.Route, h.Status, at))
+			}
+		}
+	}
+
+	s.headways = headways
+	s.status = status
+
+	for _, alert := range alerts {
+		s.shared.Logger.Infof("headway alert, data: %v", alert)
+		for _, ch := range s.subscribers {
+			select {
+			case ch <- alert:
+			default:
+			}
+		}
+	}
+}
+
+func NewHeadwayService(
+	shared shared.Holder,
+	bus bus.Service,
+	simulator simulator.Service,
+	progress progress.Service,
+	route route.Service,
+	terminal terminal.Service,
+	traveltime traveltime.Service,
+) Service {
+	return &service{
+		shared:      shared,
+		bus:         bus,
+		simulator:   simulator,
+		progress:    progress,
+		route:       route,
+		terminal:    terminal,
+		traveltime:  traveltime,
+		headways:    make([]dto.RouteHeadway, 0),
+		status:      make(map[uint]dto.HeadwayStatus),
+		subscribers: make(map[int]chan dto.HeadwayAlert),
+	}
+}
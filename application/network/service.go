@@ -0,0 +1,304 @@
+package network
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"tracking-server/application/place"
+	"tracking-server/application/route"
+	"tracking-server/application/search"
+	"tracking-server/application/shape"
+	"tracking-server/application/station"
+	"tracking-server/application/terminal"
+	"tracking-server/shared"
+	"tracking-server/shared/common"
+	"tracking-server/shared/dto"
+
+	"gopkg.in/yaml.v3"
+	"gorm.io/gorm"
+)
+
+type (
+	Service interface {
+		Seed()
+		Load(path string, force bool) (bool, error)
+		Import(network dto.Network) error
+		Export() (dto.Network, error)
+		Read(path string) (dto.Network, []byte, error)
+		Write(path string, network dto.Network) error
+	}
+	service struct {
+		shared   shared.Holder
+		route    route.Service
+		terminal terminal.Service
+		shape    shape.Service
+		place    place.Service
+		search   search.Service
+		station  station.Service
+	}
+)
+
+/**
+ * Load network file on startup, only version newer than the last import applied
+ * so change made through the admin api survive restart
+ */
+func (s *service) Seed() {
+	path := s.shared.Env.NetworkFile
+	if path == "" {
+		return
+	}
+
+	applied, err := s.Load(path, false)
+	if err != nil {
+		s.shared.Logger.Errorf("error when seeding network from %s, err: %s", path, err.Error())
+		return
+	}
+
+	if applied {
+		s.shared.Logger.Infof("network seeded from %s", path)
+	}
+}
+
+/**
+ * Read network file and import it when its version is newer than the last import
+ * * force import the file regardless of version
+ */
+func (s *service) Load(path string, force bool) (bool, error) {
+	network, content, err := s.Read(path)
+	if err != nil {
+		return false, err
+	}
+
+	if !force {
+		last := dto.NetworkImport{}
+		err := s.shared.DB.Order("version desc").Limit(1).Find(&last).Error
+		if err != nil {
+			return false, err
+		}
+
+		if last.ID != 0 && network.Version <= last.Version {
+			return false, nil
+		}
+	}
+
+	if err := s.Import(network); err != nil {
+		return false, err
+	}
+
+	checksum := sha256.Sum256(content)
+	record := dto.NetworkImport{
+		Version:    network.Version,
+		Checksum:   hex.EncodeToString(checksum[:]),
+		Source:     filepath.Base(path),
+		ImportedAt: time.Now(),
+	}
+
+	return true, s.shared.DB.Create(&record).Error
+}
+
+/**
+ * Upsert route, place, terminal and shape of the network in one transaction
+ * Route, place and terminal not in the network are kept as is,
+ * cache invalidated only after the import is committed
+ */
+func (s *service) Import(network dto.Network) error {
+	if err := common.ValidateStruct(network); err != nil {
+		return err
+	}
+
+	if err := network.Check(); err != nil {
+		return err
+	}
+
+	shapes := make(map[dto.Route][]dto.ShapePoint)
+	for _, r := range network.Routes {
+		if r.Shape == "" {
+			continue
+		}
+
+		points, err := common.DecodePolyline(r.Shape)
+		if err != nil {
+			return err
+		}
+
+		if len(points) < dto.SHAPEMINPOINT {
+			return fmt.Errorf("%w: route %s", dto.ErrShapeTooShort, r.Code)
+		}
+
+		shapes[r.Code] = dto.NewShapePoints(r.Code, common.NewShape(points))
+	}
+
+	err := s.shared.DB.Transaction(func(tx *gorm.DB) error {
+		routes := network.ToBusRoutes()
+		if err := s.route.Upsert(tx, &routes); err != nil {
+			return err
+		}
+
+		places := network.ToPlaces()
+		if err := s.place.Upsert(tx, &places); err != nil {
+			return err
+		}
+
+		placeByName := make(map[string]dto.Place, len(places))
+		for i := range places {
+			if err := s.place.ReplaceAliases(tx, &places[i], network.Places[i].Aliases); err != nil {
+				return err
+			}
+			placeByName[places[i].Name] = places[i]
+		}
+
+		terminals := network.ToTerminals()
+		if err := s.terminal.Upsert(tx, &terminals); err != nil {
+			return err
+		}
+
+		for i := range terminals {
+			around := make([]dto.Place, 0, len(network.Terminals[i].Places))
+			for _, name := range network.Terminals[i].Places {
+				around = append(around, placeByName[name])
+			}
+
+			if err := s.terminal.ReplacePlaces(tx, &terminals[i], around); err != nil {
+				return err
+			}
+		}
+
+		if err := s.station.Sync(tx); err != nil {
+			return err
+		}
+
+		for route, points := range shapes {
+			if err := s.shape.Replace(tx, route, points); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	s.route.Invalidate()
+	s.search.Invalidate()
+	for route := range shapes {
+		s.shape.Invalidate(route)
+	}
+
+	return err
+}
+
+/**
+ * Current network in the database, version continue from the last import
+ */
+func (s *service) Export() (dto.Network, error) {
+	var (
+		routes    = []dto.BusRoute{}
+		terminals = []dto.Terminal{}
+		places    = []dto.Place{}
+		last      = dto.NetworkImport{}
+		byRoute   = make(map[dto.Route][]dto.Terminal)
+	)
+
+	network := dto.Network{
+		Routes:    make([]dto.NetworkRoute, 0),
+		Terminals: make([]dto.NetworkTerminal, 0),
+		Places:    make([]dto.NetworkPlace, 0),
+	}
+
+	if err := s.shared.DB.Order("version desc").Limit(1).Find(&last).Error; err != nil {
+		return network, err
+	}
+	network.Version = last.Version + 1
+
+	if err := s.route.FindAll(&routes); err != nil {
+		return network, err
+	}
+
+	for _, r := range routes {
+		encoded := ""
+		if shape, ok := s.shape.Get(r.Code); ok {
+			encoded = common.EncodePolyline(shape.Points)
+		}
+		network.Routes = append(network.Routes, r.ToNetworkRoute(encoded))
+	}
+
+	if err := s.terminal.GetAllTerminal(&terminals); err != nil {
+		return network, err
+	}
+	for _, t := range terminals {
+		byRoute[t.Route] = append(byRoute[t.Route], t)
+	}
+
+	for _, r := range routes {
+		for _, t := range byRoute[r.Code] {
+			network.Terminals = append(network.Terminals, t.ToNetworkTerminal())
+		}
+	}
+
+	if err := s.place.FindAll(&places); err != nil {
+		return network, err
+	}
+	for _, p := range places {
+		network.Places = append(network.Places, p.ToNetworkPlace())
+	}
+
+	return network, nil
+}
+
+/**
+ * Read network file, format picked from the file extension
+ */
+func (s *service) Read(path string) (dto.Network, []byte, error) {
+	network := dto.Network{}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return network, nil, err
+	}
+
+	if isJSON(path) {
+		err = json.Unmarshal(content, &network)
+	} else {
+		err = yaml.Unmarshal(content, &network)
+	}
+
+	return network, content, err
+}
+
+func (s *service) Write(path string, network dto.Network) error {
+	var (
+		content []byte
+		err     error
+	)
+
+	if isJSON(path) {
+		content, err = json.MarshalIndent(network, "", "  ")
+	} else {
+		content, err = yaml.Marshal(network)
+	}
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, content, 0644)
+}
+
+func isJSON(path string) bool {
+	return strings.EqualFold(filepath.Ext(path), ".json")
+}
+
+func NewNetworkService(shared shared.Holder, route route.Service, terminal terminal.Service, shape shape.Service, place place.Service, search search.Service, station station.Service) Service {
+	return &service{
+		shared:   shared,
+		route:    route,
+		terminal: terminal,
+		shape:    shape,
+		place:    place,
+		search:   search,
+		station:  station,
+	}
+}
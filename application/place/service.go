@@ -0,0 +1,100 @@
+package place
+
+import (
+	"strings"
+
+	"tracking-server/shared"
+	"tracking-server/shared/dto"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type (
+	Service interface {
+		FindById(id string, data *dto.Place) error
+		FindByIds(ids []uint, data *[]dto.Place) error
+		FindAll(data *[]dto.Place) error
+		Search(query string, data *[]dto.Place) error
+		Upsert(tx *gorm.DB, data *[]dto.Place) error
+		ReplaceAliases(tx *gorm.DB, data *dto.Place, aliases []string) error
+	}
+	service struct {
+		shared shared.Holder
+	}
+)
+
+func (s *service) FindById(id string, data *dto.Place) error {
+	err := s.shared.DB.Preload("Aliases").Preload("Terminals").Where("id = ?", id).First(data).Error
+	return err
+}
+
+func (s *service) FindByIds(ids []uint, data *[]dto.Place) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	err := s.shared.DB.Where("id IN ?", ids).Find(data).Error
+	return err
+}
+
+func (s *service) FindAll(data *[]dto.Place) error {
+	err := s.shared.DB.Preload("Aliases").Preload("Terminals").Order("name").Find(data).Error
+	return err
+}
+
+/**
+ * Find place whose name, english name or alias contain the query, case insensitive
+ */
+func (s *service) Search(query string, data *[]dto.Place) error {
+	pattern := "%" + strings.ToLower(query) + "%"
+
+	err := s.shared.DB.
+		Preload("Aliases").
+		Preload("Terminals").
+		Where("LOWER(name) LIKE ? OR LOWER(name_en) LIKE ? OR id IN (?)", pattern, pattern,
+			s.shared.DB.Model(&dto.PlaceAlias{}).Select("place_id").Where("LOWER(alias) LIKE ?", pattern)).
+		Order("name").
+		Find(data).Error
+	return err
+}
+
+/**
+ * Insert place or update the existing place with the same name
+ */
+func (s *service) Upsert(tx *gorm.DB, data *[]dto.Place) error {
+	if len(*data) == 0 {
+		return nil
+	}
+
+	err := tx.Omit(clause.Associations).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "name"}},
+		DoUpdates: clause.AssignmentColumns([]string{"name_en", "category", "latitude", "longitude"}),
+	}).Create(data).Error
+	return err
+}
+
+func (s *service) ReplaceAliases(tx *gorm.DB, data *dto.Place, aliases []string) error {
+	return tx.Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("place_id = ?", data.ID).Delete(&dto.PlaceAlias{}).Error
+		if err != nil {
+			return err
+		}
+
+		data.Aliases = make([]dto.PlaceAlias, 0, len(aliases))
+		for _, a := range aliases {
+			data.Aliases = append(data.Aliases, dto.PlaceAlias{PlaceID: data.ID, Alias: a})
+		}
+
+		if len(data.Aliases) == 0 {
+			return nil
+		}
+		return tx.Create(&data.Aliases).Error
+	})
+}
+
+func NewPlaceService(shared shared.Holder) Service {
+	return &service{
+		shared: shared,
+	}
+}
@@ -0,0 +1,112 @@
+package progress
+
+import (
+	"sync"
+	"time"
+
+	"tracking-server/shared"
+	"tracking-server/shared/common"
+	"tracking-server/shared/dto"
+)
+
+type (
+	Service interface {
+		Observe(busID uint, route dto.Route, lat float64, long float64, path common.Route) dto.BusProgress
+		Get(busID uint) (dto.BusProgress, bool)
+		GetAll() []dto.BusProgress
+	}
+	service struct {
+		shared   shared.Holder
+		mu       sync.Mutex
+		progress map[uint]dto.BusProgress
+	}
+)
+
+/**
+ * Update bus progress along its route from the latest location
+ * Projection only move forward from the last visited terminal so a bus passing
+ * close to another part of the loop keeps its direction
+ */
+func (s *service) Observe(busID uint, route dto.Route, lat float64, long float64, path common.Route) dto.BusProgress {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(path.Points) == 0 {
+		return dto.BusProgress{BusID: busID, Route: route}
+	}
+
+	var (
+		now         = time.Now()
+		current, ok = s.progress[busID]
+		projection  common.RouteProjection
+	)
+
+	tracked := ok &&
+		current.Route == route &&
+		current.LastVisited < len(path.Points) &&
+		now.Sub(current.UpdatedAt) < dto.PROGRESSEXPIRY
+
+	if tracked {
+		projection = path.ProjectWithin(lat, long, current.LastVisited, dto.PROGRESSWINDOW)
+	}
+
+	if !tracked || projection.Distance > dto.PROGRESSMAXDISTANCE {
+		projection = path.Project(lat, long)
+		current = dto.BusProgress{BusID: busID, Route: route}
+	}
+
+	next := path.Next(projection.Segment)
+
+	current.Segment = projection.Segment
+	current.Offset = projection.Offset
+	current.LastVisited = projection.Segment
+	current.AtTerminal = false
+
+	radius := dto.GeofenceRadius(s.shared.Env)
+
+	switch {
+	case path.DistanceToStop(lat, long, next) <= radius:
+		current.LastVisited = next
+		current.Segment = next
+		current.Offset = 0
+		current.AtTerminal = true
+	case path.DistanceToStop(lat, long, projection.Segment) <= radius:
+		current.AtTerminal = true
+	}
+
+	current.UpdatedAt = now
+	s.progress[busID] = current
+
+	return current
+}
+
+func (s *service) Get(busID uint) (dto.BusProgress, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	progress, ok := s.progress[busID]
+	return progress, ok
+}
+
+/**
+ * Get progress of every bus still reporting
+ */
+func (s *service) GetAll() []dto.BusProgress {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	res := make([]dto.BusProgress, 0, len(s.progress))
+	for _, p := range s.progress {
+		if time.Since(p.UpdatedAt) < dto.PROGRESSEXPIRY {
+			res = append(res, p)
+		}
+	}
+	return res
+}
+
+func NewProgressService(shared shared.Holder) Service {
+	return &service{
+		shared:   shared,
+		progress: make(map[uint]dto.BusProgress),
+	}
+}
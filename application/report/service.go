@@ -0,0 +1,243 @@
+package report
+
+import (
+	"sync"
+	"time"
+
+	"tracking-server/shared"
+	"tracking-server/shared/dto"
+)
+
+type (
+	Service interface {
+		Seed()
+		Create(data *dto.RiderReport) error
+		FindById(id string, data *dto.RiderReport) error
+		FindReviewQueue(query dto.ReviewQueueQuery, data *[]dto.RiderReport) error
+		Save(data *dto.RiderReport) error
+		Allow(deviceID string, ip string, at time.Time) bool
+		Observe(data dto.RiderReport)
+		Signal(busID uint, at time.Time) dto.CrowdSignal
+	}
+	service struct {
+		shared    shared.Holder
+		mu        sync.Mutex
+		devices   map[string][]time.Time
+		crowd     map[uint][]dto.RiderReport
+		lastSweep time.Time
+	}
+)
+
+/**
+ * Rebuild rate limit window and crowd signal from stored report on startup
+ * so a restart neither reset the limit nor drop the crowd signal
+ */
+func (s *service) Seed() {
+	var (
+		reports = []dto.RiderReport{}
+		now     = time.Now()
+		limit   = now.Add(-dto.REPORTLIMITWINDOW)
+		signal  = now.Add(-dto.REPORTSIGNALWINDOW)
+	)
+
+	since := limit
+	if signal.Before(since) {
+		since = signal
+	}
+
+	err := s.shared.DB.Where("created_at > ?", since).Order("created_at").Find(&reports).Error
+	if err != nil {
+		s.shared.Logger.Errorf("error when finding recent rider report, err: %s", err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, r := range reports {
+		if r.CreatedAt.After(limit) {
+			s.record(r.DeviceID, r.ClientIP, r.CreatedAt)
+		}
+		if r.Type == dto.CROWDREPORT && r.CreatedAt.After(signal) {
+			s.crowd[r.BusID] = append(s.crowd[r.BusID], r)
+		}
+	}
+}
+
+func (s *service) Create(data *dto.RiderReport) error {
+	err := s.shared.DB.Create(data).Error
+	return err
+}
+
+func (s *service) FindById(id string, data *dto.RiderReport) error {
+	err := s.shared.DB.Where("id = ?", id).First(data).Error
+	return err
+}
+
+/**
+ * Find report with free text message, oldest pending report first
+ */
+func (s *service) FindReviewQueue(query dto.ReviewQueueQuery, data *[]dto.RiderReport) error {
+	db := s.shared.DB.Where("message <> ''").Order("created_at")
+
+	review := query.Review
+	if review == "" {
+		review = dto.PENDING
+	}
+	db = db.Where("review = ?", review)
+
+	if query.BusID != 0 {
+		db = db.Where("bus_id = ?", query.BusID)
+	}
+
+	limit := query.Limit
+	if limit <= 0 {
+		limit = dto.DEFAULTREVIEWLIMIT
+	}
+
+	err := db.Limit(limit).Find(data).Error
+	return err
+}
+
+func (s *service) Save(data *dto.RiderReport) error {
+	err := s.shared.DB.Save(data).Error
+	return err
+}
+
+/**
+ * Sliding window rate limit per device and per client ip, record the attempt when allowed
+ * Device id chosen by the client, ip limit stop rotating it to bypass the limit
+ */
+func (s *service) Allow(deviceID string, ip string, at time.Time) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.sweep(at)
+
+	since := at.Add(-dto.REPORTLIMITWINDOW)
+	device := prune(s.devices[deviceKey(deviceID)], since)
+	s.devices[deviceKey(deviceID)] = device
+
+	client := prune(s.devices[ipKey(ip)], since)
+	if ip != "" {
+		s.devices[ipKey(ip)] = client
+	}
+
+	if len(device) >= dto.REPORTLIMIT || (ip != "" && len(client) >= dto.REPORTIPLIMIT) {
+		return false
+	}
+
+	s.record(deviceID, ip, at)
+	return true
+}
+
+/**
+ * Keep crowd report in memory for the crowd signal
+ */
+func (s *service) Observe(data dto.RiderReport) {
+	if data.Type != dto.CROWDREPORT {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.crowd[data.BusID] = append(s.crowd[data.BusID], data)
+}
+
+/**
+ * Aggregate recent crowd report of a bus
+ * Only the latest report of each device counted, weighted by its age
+ */
+func (s *service) Signal(busID uint, at time.Time) dto.CrowdSignal {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var (
+		res     dto.CrowdSignal
+		since   = at.Add(-dto.REPORTSIGNALWINDOW)
+		latest  = make(map[string]dto.RiderReport)
+		recent  = make([]dto.RiderReport, 0)
+		weights float64
+	)
+
+	for _, r := range s.crowd[busID] {
+		if r.CreatedAt.Before(since) {
+			continue
+		}
+		recent = append(recent, r)
+		if current, ok := latest[r.DeviceID]; !ok || r.CreatedAt.After(current.CreatedAt) {
+			latest[r.DeviceID] = r
+		}
+	}
+
+	if len(recent) == 0 {
+		delete(s.crowd, busID)
+		return res
+	}
+	s.crowd[busID] = recent
+
+	for _, r := range latest {
+		w := r.Weight(at)
+		res.Level += r.Status.Level() * w
+		weights += w
+	}
+
+	res.Level /= weights
+	res.Confidence = weights / (weights + dto.REPORTCONFIDENCEPRIOR)
+	res.Reports = len(latest)
+
+	return res
+}
+
+/**
+ * Drop idle device and ip from the rate limiter once every window
+ */
+func (s *service) sweep(at time.Time) {
+	if at.Sub(s.lastSweep) < dto.REPORTLIMITWINDOW {
+		return
+	}
+	s.lastSweep = at
+
+	since := at.Add(-dto.REPORTLIMITWINDOW)
+	for device, attempts := range s.devices {
+		if recent := prune(attempts, since); len(recent) > 0 {
+			s.devices[device] = recent
+		} else {
+			delete(s.devices, device)
+		}
+	}
+}
+
+func (s *service) record(deviceID string, ip string, at time.Time) {
+	s.devices[deviceKey(deviceID)] = append(s.devices[deviceKey(deviceID)], at)
+	if ip != "" {
+		s.devices[ipKey(ip)] = append(s.devices[ipKey(ip)], at)
+	}
+}
+
+func deviceKey(deviceID string) string {
+	return "device:" + deviceID
+}
+
+func ipKey(ip string) string {
+	return "ip:" + ip
+}
+
+func prune(attempts []time.Time, since time.Time) []time.Time {
+	recent := make([]time.Time, 0, len(attempts))
+	for _, t := range attempts {
+		if t.After(since) {
+			recent = append(recent, t)
+		}
+	}
+	return recent
+}
+
+func NewReportService(shared shared.Holder) Service {
+	return &service{
+		shared:  shared,
+		devices: make(map[string][]time.Time),
+		crowd:   make(map[uint][]dto.RiderReport),
+	}
+}
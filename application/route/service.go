@@ -0,0 +1,183 @@
+package route
+
+import (
+	"sync"
+
+	"tracking-server/shared"
+	"tracking-server/shared/common"
+	"tracking-server/shared/dto"
+
+	"github.com/go-playground/validator/v10"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type (
+	Service interface {
+		Create(data *dto.BusRoute) error
+		FindAll(data *[]dto.BusRoute) error
+		FindByCode(code string, data *dto.BusRoute) error
+		Save(data *dto.BusRoute) error
+		Delete(code string) error
+		Upsert(tx *gorm.DB, data *[]dto.BusRoute) error
+		CountReference(code string) (int64, error)
+		Exist(code dto.Route) bool
+		GetActive() []dto.Route
+		Invalidate()
+	}
+	service struct {
+		shared shared.Holder
+		mu     sync.Mutex
+		loaded bool
+		routes []dto.BusRoute
+	}
+)
+
+func (s *service) Create(data *dto.BusRoute) error {
+	err := s.shared.DB.Create(data).Error
+	s.Invalidate()
+	return err
+}
+
+func (s *service) FindAll(data *[]dto.BusRoute) error {
+	err := s.shared.DB.Order("id").Find(data).Error
+	return err
+}
+
+func (s *service) FindByCode(code string, data *dto.BusRoute) error {
+	err := s.shared.DB.Where("code = ?", code).First(data).Error
+	return err
+}
+
+func (s *service) Save(data *dto.BusRoute) error {
+	err := s.shared.DB.Save(data).Error
+	s.Invalidate()
+	return err
+}
+
+func (s *service) Delete(code string) error {
+	err := s.shared.DB.Where("code = ?", code).Delete(&dto.BusRoute{}).Error
+	s.Invalidate()
+	return err
+}
+
+/**
+ * Insert route or update the existing route with the same code
+ * Cache not invalidated since the transaction may still roll back, caller invalidate after commit
+ */
+func (s *service) Upsert(tx *gorm.DB, data *[]dto.BusRoute) error {
+	if len(*data) == 0 {
+		return nil
+	}
+
+	err := tx.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "code"}},
+		DoUpdates: clause.AssignmentColumns([]string{"name", "color", "direction", "is_active", "description"}),
+	}).Create(data).Error
+	return err
+}
+
+/**
+ * Number of bus and terminal still on the route
+ */
+func (s *service) CountReference(code string) (int64, error) {
+	var bus, terminal int64
+
+	err := s.shared.DB.Model(&dto.Bus{}).Where("route = ?", code).Count(&bus).Error
+	if err != nil {
+		return 0, err
+	}
+
+	err = s.shared.DB.Model(&dto.Terminal{}).Where("route = ?", code).Count(&terminal).Error
+	if err != nil {
+		return 0, err
+	}
+
+	return bus + terminal, nil
+}
+
+/**
+ * Check route code registered, active or not
+ */
+func (s *service) Exist(code dto.Route) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.load()
+
+	for _, r := range s.routes {
+		if r.Code == code {
+			return true
+		}
+	}
+	return false
+}
+
+/**
+ * Code of every active route in creation order
+ */
+func (s *service) GetActive() []dto.Route {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.load()
+
+	res := make([]dto.Route, 0, len(s.routes))
+	for _, r := range s.routes {
+		if r.IsActive {
+			res = append(res, r.Code)
+		}
+	}
+	return res
+}
+
+/**
+ * Load route into memory once, reloaded after every change
+ */
+func (s *service) load() {
+	if s.loaded {
+		return
+	}
+
+	routes := []dto.BusRoute{}
+	if err := s.FindAll(&routes); err != nil {
+		s.shared.Logger.Errorf("error when finding all route, err: %s", err.Error())
+		return
+	}
+
+	s.routes = routes
+	s.loaded = true
+}
+
+func (s *service) Invalidate() {
+	s.mu.Lock()
+	s.loaded = false
+	s.mu.Unlock()
+}
+
+/**
+ * Route service also register the route validation tag
+ * so request body reference registered route instead of hardcoded code
+ */
+func NewRouteService(shared shared.Holder) (Service, error) {
+	s := &service{
+		shared: shared,
+	}
+
+	err := common.RegisterValidation("route", func(fl validator.FieldLevel) bool {
+		return s.Exist(dto.Route(fl.Field().String()))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = common.RegisterTranslation("route", map[common.Locale]string{
+		common.EN: "{0} must be a registered route",
+		common.ID: "{0} harus berupa rute yang terdaftar",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
@@ -0,0 +1,119 @@
+package sandbox
+
+import (
+	"sync"
+
+	"tracking-server/shared"
+	"tracking-server/shared/dto"
+
+	"gorm.io/gorm/clause"
+)
+
+type (
+	Service interface {
+		Create(data *dto.Sandbox) error
+		FindByName(name string, data *dto.Sandbox) error
+		Delete(data *dto.Sandbox) error
+		SaveBus(data *dto.SandboxBus) error
+		DeleteBus(sandboxID uint, experimentalID string) error
+		FindBusBySandbox(sandboxID uint, data *[]dto.SandboxBus) error
+		FindBusBySandboxName(name string, data *[]dto.SandboxBus) error
+		StoreLocation(name string, experimentalID string, location dto.BusLocationMessage)
+		GetFleet(name string) dto.SandboxFleet
+	}
+	service struct {
+		shared shared.Holder
+		mu     sync.RWMutex
+		fleets map[string]dto.SandboxFleet
+	}
+)
+
+func (s *service) Create(data *dto.Sandbox) error {
+	err := s.shared.DB.Create(data).Error
+	return err
+}
+
+func (s *service) FindByName(name string, data *dto.Sandbox) error {
+	err := s.shared.DB.Where("name = ?", name).First(data).Error
+	return err
+}
+
+/**
+ * Delete sandbox along with its bus link and fleet state
+ */
+func (s *service) Delete(data *dto.Sandbox) error {
+	err := s.shared.DB.Where("sandbox_id = ?", data.ID).Delete(&dto.SandboxBus{}).Error
+	if err != nil {
+		return err
+	}
+
+	err = s.shared.DB.Delete(data).Error
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	delete(s.fleets, data.Name)
+	s.mu.Unlock()
+
+	return nil
+}
+
+func (s *service) SaveBus(data *dto.SandboxBus) error {
+	err := s.shared.DB.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "sandbox_id"}, {Name: "experimental_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"bus_id"}),
+	}).Create(data).Error
+	return err
+}
+
+func (s *service) DeleteBus(sandboxID uint, experimentalID string) error {
+	err := s.shared.DB.Where("sandbox_id = ? AND experimental_id = ?", sandboxID, experimentalID).Delete(&dto.SandboxBus{}).Error
+	return err
+}
+
+func (s *service) FindBusBySandbox(sandboxID uint, data *[]dto.SandboxBus) error {
+	err := s.shared.DB.Preload("Bus").Where("sandbox_id = ?", sandboxID).Find(data).Error
+	return err
+}
+
+func (s *service) FindBusBySandboxName(name string, data *[]dto.SandboxBus) error {
+	err := s.shared.DB.Preload("Bus").
+		Joins("JOIN sandboxes ON sandboxes.id = sandbox_buses.sandbox_id").
+		Where("sandboxes.name = ?", name).
+		Find(data).Error
+	return err
+}
+
+func (s *service) StoreLocation(name string, experimentalID string, location dto.BusLocationMessage) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	fleet, ok := s.fleets[name]
+	if !ok {
+		fleet = make(dto.SandboxFleet)
+		s.fleets[name] = fleet
+	}
+	fleet[experimentalID] = location
+}
+
+/**
+ * Get copy of the fleet state of a sandbox
+ */
+func (s *service) GetFleet(name string) dto.SandboxFleet {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	fleet := make(dto.SandboxFleet, len(s.fleets[name]))
+	for id, location := range s.fleets[name] {
+		fleet[id] = location
+	}
+	return fleet
+}
+
+func NewSandboxService(shared shared.Holder) Service {
+	return &service{
+		shared: shared,
+		fleets: make(map[string]dto.SandboxFleet),
+	}
+}
@@ -0,0 +1,221 @@
+package schedule
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"tracking-server/shared"
+	"tracking-server/shared/dto"
+)
+
+type (
+	Service interface {
+		CreateWindow(data *dto.OperatingWindow) error
+		DeleteWindow(id string) error
+		FindAllWindow(data *[]dto.OperatingWindow) error
+		CreateException(data *dto.ServiceException) error
+		DeleteException(id string) error
+		FindAllException(data *[]dto.ServiceException) error
+		Status(route dto.Route, at time.Time) dto.RouteServiceStatus
+	}
+	service struct {
+		shared     shared.Holder
+		mu         sync.Mutex
+		loaded     bool
+		windows    []dto.OperatingWindow
+		exceptions []dto.ServiceException
+	}
+)
+
+func (s *service) CreateWindow(data *dto.OperatingWindow) error {
+	err := s.shared.DB.Create(data).Error
+	s.invalidate()
+	return err
+}
+
+func (s *service) DeleteWindow(id string) error {
+	err := s.shared.DB.Where("id = ?", id).Delete(&dto.OperatingWindow{}).Error
+	s.invalidate()
+	return err
+}
+
+func (s *service) FindAllWindow(data *[]dto.OperatingWindow) error {
+	err := s.shared.DB.Order("route, day_type, start_time").Find(data).Error
+	return err
+}
+
+func (s *service) CreateException(data *dto.ServiceException) error {
+	err := s.shared.DB.Create(data).Error
+	s.invalidate()
+	return err
+}
+
+func (s *service) DeleteException(id string) error {
+	err := s.shared.DB.Where("id = ?", id).Delete(&dto.ServiceException{}).Error
+	s.invalidate()
+	return err
+}
+
+func (s *service) FindAllException(data *[]dto.ServiceException) error {
+	err := s.shared.DB.Order("start_date").Find(data).Error
+	return err
+}
+
+/**
+ * Check whether a route is operating and when it next starts
+ * Route without any operating window is considered always in service
+ */
+func (s *service) Status(route dto.Route, at time.Time) dto.RouteServiceStatus {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.load()
+
+	var (
+		res   = dto.RouteServiceStatus{Route: route}
+		local = at.In(dto.WIB)
+		today = time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, dto.WIB)
+	)
+
+	if !s.scheduled(route) {
+		res.InService = true
+		return res
+	}
+	res.Scheduled = true
+
+	dayType, reason := s.dayType(route, today)
+	res.DayType = dayType
+	res.Reason = reason
+
+	for _, w := range s.dayWindows(route, dayType) {
+		start, end := clock(today, w.Start), clock(today, w.End)
+		if !local.Before(start) && local.Before(end) {
+			res.InService = true
+			res.Reason = ""
+			res.Until = &end
+			return res
+		}
+	}
+
+	for day := 0; day <= dto.SCHEDULELOOKAHEAD; day++ {
+		date := today.AddDate(0, 0, day)
+		dayType, _ := s.dayType(route, date)
+		for _, w := range s.dayWindows(route, dayType) {
+			if start := clock(date, w.Start); start.After(local) {
+				res.NextStart = &start
+				if res.Reason == "" {
+					res.Reason = "outside operating hour"
+				}
+				return res
+			}
+		}
+	}
+
+	if res.Reason == "" {
+		res.Reason = "no upcoming service"
+	}
+
+	return res
+}
+
+/**
+ * Day type of a date, exception take precedence over the weekday
+ * Holiday return empty day type since no window applies
+ */
+func (s *service) dayType(route dto.Route, date time.Time) (dto.DayType, string) {
+	key := date.Format(dto.DATEFORMAT)
+
+	for _, e := range s.exceptions {
+		if e.Type == dto.HOLIDAY && e.Covers(route, key) {
+			if e.Description == "" {
+				return "", "holiday"
+			}
+			return "", fmt.Sprintf("holiday: %s", e.Description)
+		}
+	}
+	for _, e := range s.exceptions {
+		if e.Type == dto.SEMESTERBREAK && e.Covers(route, key) {
+			return dto.BREAK, ""
+		}
+	}
+
+	switch date.Weekday() {
+	case time.Saturday:
+		return dto.SATURDAY, ""
+	case time.Sunday:
+		return dto.SUNDAY, ""
+	default:
+		return dto.WEEKDAY, ""
+	}
+}
+
+func (s *service) dayWindows(route dto.Route, dayType dto.DayType) []dto.OperatingWindow {
+	res := make([]dto.OperatingWindow, 0)
+	for _, w := range s.windows {
+		if w.Route == route && w.DayType == dayType {
+			res = append(res, w)
+		}
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		return clock(time.Time{}, res[i].Start).Before(clock(time.Time{}, res[j].Start))
+	})
+
+	return res
+}
+
+func (s *service) scheduled(route dto.Route) bool {
+	for _, w := range s.windows {
+		if w.Route == route {
+			return true
+		}
+	}
+	return false
+}
+
+/**
+ * Load schedule into memory once, reloaded after every change
+ */
+func (s *service) load() {
+	if s.loaded {
+		return
+	}
+
+	windows := []dto.OperatingWindow{}
+	if err := s.FindAllWindow(&windows); err != nil {
+		s.shared.Logger.Errorf("error when finding operating window, err: %s", err.Error())
+		return
+	}
+
+	exceptions := []dto.ServiceException{}
+	if err := s.FindAllException(&exceptions); err != nil {
+		s.shared.Logger.Errorf("error when finding service exception, err: %s", err.Error())
+		return
+	}
+
+	s.windows = windows
+	s.exceptions = exceptions
+	s.loaded = true
+}
+
+func (s *service) invalidate() {
+	s.mu.Lock()
+	s.loaded = false
+	s.mu.Unlock()
+}
+
+func clock(date time.Time, value string) time.Time {
+	t, err := time.Parse(dto.CLOCKFORMAT, value)
+	if err != nil {
+		return date
+	}
+	return date.Add(time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute)
+}
+
+func NewScheduleService(shared shared.Holder) Service {
+	return &service{
+		shared: shared,
+	}
+}
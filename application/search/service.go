@@ -0,0 +1,239 @@
+package search
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"tracking-server/application/news"
+	"tracking-server/application/place"
+	"tracking-server/application/terminal"
+	"tracking-server/shared"
+	"tracking-server/shared/common"
+	"tracking-server/shared/dto"
+)
+
+type (
+	Service interface {
+		Search(query dto.SearchQuery, locale common.Locale) ([]dto.SearchResult, error)
+		Invalidate()
+	}
+	service struct {
+		shared    shared.Holder
+		news      news.Service
+		terminal  terminal.Service
+		place     place.Service
+		mu        sync.Mutex
+		documents []dto.SearchDocument
+		builtAt   time.Time
+		stale     bool
+	}
+)
+
+/**
+ * Search terminal, place and news title in any language, best score first
+ * Result near the rider get a bonus when rider location is given
+ */
+func (s *service) Search(query dto.SearchQuery, locale common.Locale) ([]dto.SearchResult, error) {
+	var (
+		res     = make([]dto.SearchResult, 0)
+		q       = common.NormalizeText(query.Q)
+		located = query.Lat != 0 || query.Long != 0
+		limit   = query.Limit
+	)
+
+	if limit == 0 {
+		limit = dto.DEFAULTSEARCHLIMIT
+	}
+
+	documents, err := s.index()
+	if err != nil {
+		return res, err
+	}
+
+	for _, d := range documents {
+		if query.Type != "" && d.Type != query.Type {
+			continue
+		}
+
+		score := match(q, d)
+		if score == 0 {
+			continue
+		}
+
+		var distance *float64
+		if located && d.Located {
+			km := common.Distance(query.Lat, query.Long, d.Lat, d.Long)
+			distance = &km
+			score += dto.SEARCHNEARBYBONUS * math.Max(0, 1-km/dto.SEARCHNEARBYRADIUS)
+		}
+
+		res = append(res, d.ToSearchResult(math.Round(score*100)/100, distance, locale))
+	}
+
+	sort.SliceStable(res, func(i, j int) bool {
+		if res[i].Score != res[j].Score {
+			return res[i].Score > res[j].Score
+		}
+		if res[i].Distance != nil && res[j].Distance != nil && *res[i].Distance != *res[j].Distance {
+			return *res[i].Distance < *res[j].Distance
+		}
+		return res[i].Title < res[j].Title
+	})
+
+	if len(res) > limit {
+		res = res[:limit]
+	}
+
+	return res, nil
+}
+
+/**
+ * Mark index to be rebuilt on the next search, called after terminal, place or news change
+ */
+func (s *service) Invalidate() {
+	s.mu.Lock()
+	s.stale = true
+	s.mu.Unlock()
+}
+
+func (s *service) index() ([]dto.SearchDocument, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.stale && time.Since(s.builtAt) < dto.SEARCHINDEXTTL {
+		return s.documents, nil
+	}
+
+	documents, err := s.build()
+	if err != nil {
+		s.shared.Logger.Errorf("error when building search index, err: %s", err.Error())
+		return s.documents, err
+	}
+
+	s.documents = documents
+	s.builtAt = time.Now()
+	s.stale = false
+
+	return s.documents, nil
+}
+
+func (s *service) build() ([]dto.SearchDocument, error) {
+	var (
+		terminals = []dto.Terminal{}
+		places    = []dto.Place{}
+		news      = dto.NewsSlice{}
+		documents = make([]dto.SearchDocument, 0)
+	)
+
+	if err := s.terminal.GetAllTerminal(&terminals); err != nil {
+		return nil, err
+	}
+	if err := s.place.FindAll(&places); err != nil {
+		return nil, err
+	}
+	if err := s.news.GetAll(&news); err != nil {
+		return nil, err
+	}
+
+	for _, t := range terminals {
+		related := make([]string, 0)
+		placesEn := make([]string, 0, len(t.Places))
+		for _, p := range t.Places {
+			related = append(related, names(p.Name, p.NameEn)...)
+			for _, a := range p.Aliases {
+				related = append(related, common.NormalizeText(a.Alias))
+			}
+			placesEn = append(placesEn, common.Localize(common.EN, p.Name, p.NameEn))
+		}
+
+		documents = append(documents, dto.SearchDocument{
+			Type:       dto.TERMINALRESULT,
+			ID:         t.ID,
+			Title:      t.Name,
+			TitleEn:    t.NameEn,
+			Subtitle:   strings.Join(t.PlaceNames(), ", "),
+			SubtitleEn: strings.Join(placesEn, ", "),
+			Route:      t.Route,
+			Lat:        t.Lat,
+			Long:       t.Long,
+			Located:    true,
+			Keys:       names(t.Name, t.NameEn),
+			Related:    related,
+		})
+	}
+
+	for _, p := range places {
+		keys := names(p.Name, p.NameEn)
+		for _, a := range p.Aliases {
+			keys = append(keys, common.NormalizeText(a.Alias))
+		}
+
+		related := make([]string, 0)
+		for _, t := range p.Terminals {
+			related = append(related, names(t.Name, t.NameEn)...)
+		}
+
+		documents = append(documents, dto.SearchDocument{
+			Type:     dto.PLACERESULT,
+			ID:       p.ID,
+			Title:    p.Name,
+			TitleEn:  p.NameEn,
+			Subtitle: string(p.Category),
+			Lat:      p.Lat,
+			Long:     p.Long,
+			Located:  true,
+			Keys:     keys,
+			Related:  related,
+		})
+	}
+
+	for _, n := range news {
+		documents = append(documents, dto.SearchDocument{
+			Type:     dto.NEWSRESULT,
+			ID:       n.ID,
+			Title:    n.Title,
+			TitleEn:  n.TitleEn,
+			Subtitle: n.CreatedAt.In(dto.WIB).Format(dto.DATEFORMAT),
+			Keys:     names(n.Title, n.TitleEn),
+		})
+	}
+
+	return documents, nil
+}
+
+/**
+ * Normalized default and english text, english left out when not translated
+ */
+func names(text string, english string) []string {
+	res := []string{common.NormalizeText(text)}
+	if english != "" {
+		res = append(res, common.NormalizeText(english))
+	}
+	return res
+}
+
+func match(query string, d dto.SearchDocument) float64 {
+	best := 0.0
+
+	for _, k := range d.Keys {
+		best = math.Max(best, common.MatchScore(query, k))
+	}
+	for _, k := range d.Related {
+		best = math.Max(best, dto.SEARCHRELATEDWEIGHT*common.MatchScore(query, k))
+	}
+
+	return best
+}
+
+func NewSearchService(shared shared.Holder, news news.Service, terminal terminal.Service, place place.Service) Service {
+	return &service{
+		shared:   shared,
+		news:     news,
+		terminal: terminal,
+		place:    place,
+		stale:    true,
+	}
+}
@@ -0,0 +1,88 @@
+package shape
+
+import (
+	"sync"
+
+	"tracking-server/shared"
+	"tracking-server/shared/common"
+	"tracking-server/shared/dto"
+
+	"gorm.io/gorm"
+)
+
+type (
+	Service interface {
+		Replace(tx *gorm.DB, route dto.Route, points []dto.ShapePoint) error
+		FindByRoute(route dto.Route, data *[]dto.ShapePoint) error
+		Delete(route dto.Route) error
+		Get(route dto.Route) (common.Shape, bool)
+		Invalidate(route dto.Route)
+	}
+	service struct {
+		shared shared.Holder
+		mu     sync.Mutex
+		shapes map[dto.Route]common.Shape
+	}
+)
+
+/**
+ * Replace every point of the route shape in one transaction
+ */
+func (s *service) Replace(tx *gorm.DB, route dto.Route, points []dto.ShapePoint) error {
+	err := tx.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("route = ?", route).Delete(&dto.ShapePoint{}).Error; err != nil {
+			return err
+		}
+		return tx.CreateInBatches(points, 500).Error
+	})
+
+	s.Invalidate(route)
+	return err
+}
+
+func (s *service) FindByRoute(route dto.Route, data *[]dto.ShapePoint) error {
+	err := s.shared.DB.Where("route = ?", route).Order("sequence").Find(data).Error
+	return err
+}
+
+func (s *service) Delete(route dto.Route) error {
+	err := s.shared.DB.Where("route = ?", route).Delete(&dto.ShapePoint{}).Error
+	s.Invalidate(route)
+	return err
+}
+
+/**
+ * Shape of the route with its precomputed distance, loaded once and kept until replaced
+ */
+func (s *service) Get(route dto.Route) (common.Shape, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if shape, ok := s.shapes[route]; ok {
+		return shape, len(shape.Points) > 0
+	}
+
+	points := []dto.ShapePoint{}
+	if err := s.FindByRoute(route, &points); err != nil {
+		s.shared.Logger.Errorf("error when finding shape by route, err: %s", err.Error())
+		return common.Shape{}, false
+	}
+
+	shape := dto.ShapePointSlice(points).ToShape()
+	s.shapes[route] = shape
+
+	return shape, len(shape.Points) > 0
+}
+
+func (s *service) Invalidate(route dto.Route) {
+	s.mu.Lock()
+	delete(s.shapes, route)
+	s.mu.Unlock()
+}
+
+func NewShapeService(shared shared.Holder) Service {
+	return &service{
+		shared: shared,
+		shapes: make(map[dto.Route]common.Shape),
+	}
+}
@@ -0,0 +1,167 @@
+package simulator
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+
+	"tracking-server/application/progress"
+	"tracking-server/application/route"
+	"tracking-server/application/terminal"
+	"tracking-server/shared"
+	"tracking-server/shared/common"
+	"tracking-server/shared/dto"
+)
+
+var (
+	crowdStatus = []dto.BusStatus{dto.EMPTY, dto.MODERATE, dto.FULL}
+)
+
+type (
+	Service interface {
+		Run()
+		GetFleet() []dto.TrackLocationResponse
+	}
+	service struct {
+		shared    shared.Holder
+		route     route.Service
+		terminal  terminal.Service
+		progress  progress.Service
+		mu        sync.RWMutex
+		fleet     []*dto.SimulatedBus
+		terminals map[dto.Route][]dto.Terminal
+		paths     map[dto.Route]common.Route
+	}
+)
+
+/**
+ * Spawn virtual bus for each active route and move them every tick
+ * Simulator disabled when bus per route is not set
+ */
+func (s *service) Run() {
+	if s.shared.Env.SimulatorBusPerRoute <= 0 {
+		return
+	}
+
+	s.spawn()
+
+	s.mu.RLock()
+	count := len(s.fleet)
+	s.mu.RUnlock()
+
+	s.shared.Logger.Infof("simulator started, bus: %d", count)
+
+	ticker := time.NewTicker(dto.SIMULATORTICK)
+	defer ticker.Stop()
+
+	for now := range ticker.C {
+		s.mu.Lock()
+		for _, b := range s.fleet {
+			s.move(b, now)
+			s.progress.Observe(b.ID, b.Route, b.Lat, b.Long, s.paths[b.Route])
+		}
+		s.mu.Unlock()
+	}
+}
+
+func (s *service) GetFleet() []dto.TrackLocationResponse {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	res := make([]dto.TrackLocationResponse, 0, len(s.fleet))
+	for _, b := range s.fleet {
+		res = append(res, b.ToTrackLocationResponse())
+	}
+	return res
+}
+
+/**
+ * Create virtual bus spread evenly along the ordered terminal of each route
+ * Fleet built aside then swapped in, handler may already read the fleet
+ */
+func (s *service) spawn() {
+	var (
+		count     = s.shared.Env.SimulatorBusPerRoute
+		id        = uint(1)
+		fleet     = make([]*dto.SimulatedBus, 0)
+		terminals = make(map[dto.Route][]dto.Terminal)
+		paths     = make(map[dto.Route]common.Route)
+	)
+
+	for _, route := range s.route.GetActive() {
+		stops := []dto.Terminal{}
+		err := s.terminal.GetAllByRoute(route, &stops)
+		if err != nil {
+			s.shared.Logger.Errorf("error when finding all terminal by route, err: %s", err.Error())
+			continue
+		}
+
+		if len(stops) < 2 {
+			continue
+		}
+		terminals[route] = stops
+		paths[route] = dto.TerminalSlice(stops).ToRoute()
+
+		for i := 0; i < count; i++ {
+			b := dto.NewSimulatedBus(id, i+1, route)
+			b.Segment = i * len(stops) / count
+			b.Lat = stops[b.Segment].Lat
+			b.Long = stops[b.Segment].Long
+			b.Status = crowdStatus[rand.Intn(len(crowdStatus))]
+			fleet = append(fleet, b)
+			id++
+		}
+	}
+
+	s.mu.Lock()
+	s.fleet = fleet
+	s.terminals = terminals
+	s.paths = paths
+	s.mu.Unlock()
+}
+
+/**
+ * Move bus along its current segment, dwell when reaching terminal
+ */
+func (s *service) move(b *dto.SimulatedBus, now time.Time) {
+	if now.Before(b.DwellUntil) {
+		b.Speed = 0
+		return
+	}
+
+	terminals := s.terminals[b.Route]
+	from := terminals[b.Segment]
+	to := terminals[(b.Segment+1)%len(terminals)]
+
+	// jitter speed so virtual bus does not move in lockstep
+	b.Speed = s.shared.Env.SimulatorSpeed * (0.8 + rand.Float64()*0.4)
+	b.Travelled += b.Speed * dto.SIMULATORTICK.Seconds()
+	b.Heading = common.Bearing(from.Lat, from.Long, to.Lat, to.Long)
+
+	length := common.Distance(from.Lat, from.Long, to.Lat, to.Long) * 1000
+	if b.Travelled >= length {
+		b.Segment = (b.Segment + 1) % len(terminals)
+		b.Travelled = 0
+		b.Lat = to.Lat
+		b.Long = to.Long
+		b.Speed = 0
+		b.Status = crowdStatus[rand.Intn(len(crowdStatus))]
+		b.DwellUntil = now.Add(time.Duration(s.shared.Env.SimulatorDwell) * time.Second)
+		return
+	}
+
+	ratio := b.Travelled / length
+	b.Lat = from.Lat + (to.Lat-from.Lat)*ratio
+	b.Long = from.Long + (to.Long-from.Long)*ratio
+}
+
+func NewSimulatorService(shared shared.Holder, route route.Service, terminal terminal.Service, progress progress.Service) Service {
+	return &service{
+		shared:    shared,
+		route:     route,
+		terminal:  terminal,
+		progress:  progress,
+		terminals: make(map[dto.Route][]dto.Terminal),
+		paths:     make(map[dto.Route]common.Route),
+	}
+}
@@ -0,0 +1,89 @@
+package station
+
+import (
+	"tracking-server/shared"
+	"tracking-server/shared/dto"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type (
+	Service interface {
+		Seed()
+		Sync(tx *gorm.DB) error
+		FindAll(data *[]dto.Station) error
+		FindById(id string, data *dto.Station) error
+	}
+	service struct {
+		shared shared.Holder
+	}
+)
+
+/**
+ * Group terminal into station on startup, terminal created before station existed included
+ */
+func (s *service) Seed() {
+	if err := s.Sync(s.shared.DB); err != nil {
+		s.shared.Logger.Errorf("error when syncing station, err: %s", err.Error())
+	}
+}
+
+/**
+ * Rebuild station from terminal name, called after every terminal change
+ * Station keep its id as long as a terminal with its name exist,
+ * station without terminal removed
+ */
+func (s *service) Sync(tx *gorm.DB) error {
+	terminals := []dto.Terminal{}
+
+	err := tx.Order("route, sequence, id").Find(&terminals).Error
+	if err != nil {
+		return err
+	}
+
+	stations := dto.TerminalSlice(terminals).ToStations()
+
+	return tx.Transaction(func(tx *gorm.DB) error {
+		if len(stations) > 0 {
+			err := tx.Omit(clause.Associations).Clauses(clause.OnConflict{
+				Columns:   []clause.Column{{Name: "name"}},
+				DoUpdates: clause.AssignmentColumns([]string{"name_en", "longitude", "latitude"}),
+			}).Create(&stations).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		for _, st := range stations {
+			err := tx.Model(&dto.Terminal{}).Where("name = ?", st.Name).Update("station_id", st.ID).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		return tx.Where("id NOT IN (?)",
+			tx.Model(&dto.Terminal{}).Select("station_id").Where("station_id IS NOT NULL")).
+			Delete(&dto.Station{}).Error
+	})
+}
+
+func (s *service) FindAll(data *[]dto.Station) error {
+	err := s.shared.DB.Preload("Terminals", func(db *gorm.DB) *gorm.DB {
+		return db.Order("route, sequence, id")
+	}).Preload("Terminals.Places").Order("name").Find(data).Error
+	return err
+}
+
+func (s *service) FindById(id string, data *dto.Station) error {
+	err := s.shared.DB.Preload("Terminals", func(db *gorm.DB) *gorm.DB {
+		return db.Order("route, sequence, id")
+	}).Preload("Terminals.Places").Where("id = ?", id).First(data).Error
+	return err
+}
+
+func NewStationService(shared shared.Holder) Service {
+	return &service{
+		shared: shared,
+	}
+}
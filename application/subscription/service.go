@@ -0,0 +1,138 @@
+package subscription
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"tracking-server/shared"
+	"tracking-server/shared/dto"
+)
+
+type (
+	Service interface {
+		Create(data *dto.ArrivalSubscription) error
+		FindByKey(key string, data *dto.ArrivalSubscription) error
+		FindPending(at time.Time, data *[]dto.ArrivalSubscription) error
+		MarkNotified(data *dto.ArrivalSubscription) error
+		Delete(key string) error
+		DeleteExpired(at time.Time) (int64, error)
+		Publish(notification dto.ArrivalNotification)
+		Subscribe(key string) (<-chan dto.ArrivalNotification, func())
+		SendWebhook(notification dto.ArrivalNotification) error
+	}
+	service struct {
+		shared      shared.Holder
+		client      *http.Client
+		mu          sync.Mutex
+		subscribers map[string][]chan dto.ArrivalNotification
+	}
+)
+
+func (s *service) Create(data *dto.ArrivalSubscription) error {
+	err := s.shared.DB.Create(data).Error
+	return err
+}
+
+func (s *service) FindByKey(key string, data *dto.ArrivalSubscription) error {
+	err := s.shared.DB.Where("key = ?", key).First(data).Error
+	return err
+}
+
+/**
+ * Find subscription not yet notified and not expired
+ */
+func (s *service) FindPending(at time.Time, data *[]dto.ArrivalSubscription) error {
+	err := s.shared.DB.Where("notified_at IS NULL AND expires_at > ?", at).Find(data).Error
+	return err
+}
+
+func (s *service) MarkNotified(data *dto.ArrivalSubscription) error {
+	err := s.shared.DB.Model(data).Select("notified_at", "bus_id", "bus_number", "bus_plate", "estimate").Updates(data).Error
+	return err
+}
+
+func (s *service) Delete(key string) error {
+	err := s.shared.DB.Where("key = ?", key).Delete(&dto.ArrivalSubscription{}).Error
+	return err
+}
+
+func (s *service) DeleteExpired(at time.Time) (int64, error) {
+	res := s.shared.DB.Where("expires_at <= ?", at).Delete(&dto.ArrivalSubscription{})
+	return res.RowsAffected, res.Error
+}
+
+/**
+ * Deliver notification to every rider websocket waiting on the subscription key
+ */
+func (s *service) Publish(notification dto.ArrivalNotification) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, ch := range s.subscribers[notification.Key] {
+		select {
+		case ch <- notification:
+		default:
+		}
+	}
+}
+
+/**
+ * Wait for notification of a subscription key, call the returned function to unsubscribe
+ */
+func (s *service) Subscribe(key string) (<-chan dto.ArrivalNotification, func()) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ch := make(chan dto.ArrivalNotification, 1)
+	s.subscribers[key] = append(s.subscribers[key], ch)
+
+	return ch, func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		subscribers := s.subscribers[key]
+		for i, c := range subscribers {
+			if c == ch {
+				s.subscribers[key] = append(subscribers[:i], subscribers[i+1:]...)
+				break
+			}
+		}
+		if len(s.subscribers[key]) == 0 {
+			delete(s.subscribers, key)
+		}
+	}
+}
+
+/**
+ * Post notification to the configured notification service
+ */
+func (s *service) SendWebhook(notification dto.ArrivalNotification) error {
+	body, err := json.Marshal(notification)
+	if err != nil {
+		return err
+	}
+
+	res, err := s.client.Post(s.shared.Env.NotificationWebhookURL, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("webhook responded with status %d", res.StatusCode)
+	}
+
+	return nil
+}
+
+func NewSubscriptionService(shared shared.Holder) Service {
+	return &service{
+		shared:      shared,
+		client:      &http.Client{Timeout: dto.WEBHOOKTIMEOUT},
+		subscribers: make(map[string][]chan dto.ArrivalNotification),
+	}
+}
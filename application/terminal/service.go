@@ -1,15 +1,27 @@
 package terminal
 
 import (
+	"sort"
+
 	"tracking-server/shared"
 	"tracking-server/shared/dto"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type (
 	Service interface {
 		GetById(id string, data *dto.Terminal) error
+		GetByIds(ids []uint, data *[]dto.Terminal) error
 		GetAllByRoute(route dto.Route, data *[]dto.Terminal) error
 		GetAllTerminal(data *[]dto.Terminal) error
+		Create(data *dto.Terminal) error
+		Save(data *dto.Terminal) error
+		Delete(data *dto.Terminal) error
+		Reorder(route dto.Route, ids []uint) error
+		Upsert(tx *gorm.DB, data *[]dto.Terminal) error
+		ReplacePlaces(tx *gorm.DB, data *dto.Terminal, places []dto.Place) error
 	}
 	service struct {
 		shared shared.Holder
@@ -17,20 +29,182 @@ type (
 )
 
 func (s *service) GetById(id string, data *dto.Terminal) error {
-	err := s.shared.DB.Where("id = ?", id).First(data).Error
+	err := s.shared.DB.Preload("Places").Where("id = ?", id).First(data).Error
+	return err
+}
+
+func (s *service) GetByIds(ids []uint, data *[]dto.Terminal) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	err := s.shared.DB.Where("id IN ?", ids).Find(data).Error
 	return err
 }
 
 func (s *service) GetAllByRoute(route dto.Route, data *[]dto.Terminal) error {
-	err := s.shared.DB.Where("route = ?", route).Find(data).Error
+	err := s.shared.DB.Where("route = ?", route).Order("sequence, id").Find(data).Error
 	return err
 }
 
 func (s *service) GetAllTerminal(data *[]dto.Terminal) error {
-	err := s.shared.DB.Find(data).Error
+	err := s.shared.DB.Preload("Places.Aliases").Order("route, sequence, id").Find(data).Error
+	return err
+}
+
+/**
+ * Insert terminal at its sequence, terminal at or after it shifted back
+ * * empty or out of range sequence append the terminal at the end of the route
+ */
+func (s *service) Create(data *dto.Terminal) error {
+	return s.shared.DB.Transaction(func(tx *gorm.DB) error {
+		var last int
+
+		err := tx.Model(&dto.Terminal{}).
+			Where("route = ?", data.Route).
+			Select("COALESCE(MAX(sequence), 0)").
+			Scan(&last).Error
+		if err != nil {
+			return err
+		}
+
+		if data.Sequence <= 0 || data.Sequence > last {
+			data.Sequence = last + 1
+		}
+
+		err = tx.Model(&dto.Terminal{}).
+			Where("route = ? AND sequence >= ?", data.Route, data.Sequence).
+			Update("sequence", gorm.Expr("sequence + 1")).Error
+		if err != nil {
+			return err
+		}
+
+		return tx.Create(data).Error
+	})
+}
+
+func (s *service) Save(data *dto.Terminal) error {
+	err := s.shared.DB.Omit(clause.Associations).Save(data).Error
+	return err
+}
+
+/**
+ * Delete terminal and close the gap it left in the route sequence
+ */
+func (s *service) Delete(data *dto.Terminal) error {
+	return s.shared.DB.Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(data).Association("Places").Clear()
+		if err != nil {
+			return err
+		}
+
+		err = tx.Exec("DELETE FROM closure_terminals WHERE terminal_id = ?", data.ID).Error
+		if err != nil {
+			return err
+		}
+
+		err = tx.Delete(&dto.Terminal{}, data.ID).Error
+		if err != nil {
+			return err
+		}
+
+		return tx.Model(&dto.Terminal{}).
+			Where("route = ? AND sequence > ?", data.Route, data.Sequence).
+			Update("sequence", gorm.Expr("sequence - 1")).Error
+	})
+}
+
+/**
+ * Set terminal sequence of a route following the given id order
+ */
+func (s *service) Reorder(route dto.Route, ids []uint) error {
+	return s.shared.DB.Transaction(func(tx *gorm.DB) error {
+		return reorder(tx, route, ids)
+	})
+}
+
+/**
+ * Insert terminal or update the existing terminal with the same name on the route
+ * Route renumbered afterwards, terminal not in the data kept after the given terminal
+ * in their previous order so no two terminal share a sequence
+ */
+func (s *service) Upsert(tx *gorm.DB, data *[]dto.Terminal) error {
+	var (
+		routes = make([]dto.Route, 0)
+		given  = make(map[dto.Route][]dto.Terminal)
+	)
+
+	if len(*data) == 0 {
+		return nil
+	}
+
+	err := tx.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "name"}, {Name: "route"}},
+		DoUpdates: clause.AssignmentColumns([]string{"name_en", "longitude", "latitude", "sequence"}),
+	}).Create(data).Error
+	if err != nil {
+		return err
+	}
+
+	for _, t := range *data {
+		if _, ok := given[t.Route]; !ok {
+			routes = append(routes, t.Route)
+		}
+		given[t.Route] = append(given[t.Route], t)
+	}
+
+	for _, route := range routes {
+		terminals := []dto.Terminal{}
+
+		err := tx.Where("route = ?", route).Order("sequence, id").Find(&terminals).Error
+		if err != nil {
+			return err
+		}
+
+		sort.SliceStable(given[route], func(i, j int) bool {
+			return given[route][i].Sequence < given[route][j].Sequence
+		})
+
+		ids := make([]uint, 0, len(terminals))
+		seen := make(map[uint]bool, len(given[route]))
+		for _, t := range given[route] {
+			ids = append(ids, t.ID)
+			seen[t.ID] = true
+		}
+		for _, t := range terminals {
+			if !seen[t.ID] {
+				ids = append(ids, t.ID)
+			}
+		}
+
+		if err := reorder(tx, route, ids); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+/**
+ * Set place around the terminal, empty place remove every relation
+ */
+func (s *service) ReplacePlaces(tx *gorm.DB, data *dto.Terminal, places []dto.Place) error {
+	err := tx.Model(data).Association("Places").Replace(places)
 	return err
 }
 
+func reorder(tx *gorm.DB, route dto.Route, ids []uint) error {
+	for i, id := range ids {
+		err := tx.Model(&dto.Terminal{}).
+			Where("id = ? AND route = ?", id, route).
+			Update("sequence", i+1).Error
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func NewTerminalService(shared shared.Holder) Service {
 	return &service{
 		shared: shared,
@@ -0,0 +1,231 @@
+package traveltime
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"tracking-server/application/bus"
+	"tracking-server/application/route"
+	"tracking-server/application/terminal"
+	"tracking-server/shared"
+	"tracking-server/shared/common"
+	"tracking-server/shared/dto"
+
+	"gorm.io/gorm"
+)
+
+type (
+	Service interface {
+		Run()
+		Recompute() error
+		SegmentTime(from uint, to uint, at time.Time) (float64, bool)
+	}
+	service struct {
+		shared   shared.Holder
+		bus      bus.Service
+		route    route.Service
+		terminal terminal.Service
+		mu       sync.RWMutex
+		model    map[dto.SegmentBucket]dto.SegmentTravelTime
+	}
+
+	visit struct {
+		index int
+		at    time.Time
+	}
+)
+
+/**
+ * Load the last model then recompute it periodically
+ */
+func (s *service) Run() {
+	stored := []dto.SegmentTravelTime{}
+	err := s.shared.DB.Find(&stored).Error
+	if err != nil {
+		s.shared.Logger.Errorf("error when loading segment travel time, err: %s", err.Error())
+	}
+	s.setModel(stored)
+
+	interval := time.Duration(s.shared.Env.TravelTimeRefreshInterval) * time.Minute
+	if interval <= 0 {
+		return
+	}
+
+	for {
+		err := s.Recompute()
+		if err != nil {
+			s.shared.Logger.Errorf("error when recomputing segment travel time, err: %s", err.Error())
+		}
+		time.Sleep(interval)
+	}
+}
+
+/**
+ * Build segment travel time from bus location history
+ * Arrival detected when bus inside terminal radius, travel time is the time
+ * between arrival on two consecutive terminal of the bus route
+ */
+func (s *service) Recompute() error {
+	var (
+		buses     = []dto.Bus{}
+		busRoute  = make(map[uint]dto.Route)
+		terminals = make(map[dto.Route]dto.TerminalSlice)
+		paths     = make(map[dto.Route]common.Route)
+		samples   = make(map[dto.SegmentBucket][]float64)
+		segments  = make(map[dto.SegmentBucket]dto.Route)
+		since     = time.Now().AddDate(0, 0, -s.shared.Env.TravelTimeHistoryDays)
+	)
+
+	for _, route := range s.route.GetActive() {
+		data := []dto.Terminal{}
+		if err := s.terminal.GetAllByRoute(route, &data); err != nil {
+			return err
+		}
+		terminals[route] = data
+		paths[route] = terminals[route].ToRoute()
+	}
+
+	if err := s.bus.FindAllBus(&buses); err != nil {
+		return err
+	}
+	for _, b := range buses {
+		busRoute[b.ID] = b.Route
+	}
+
+	rows, err := s.shared.DB.Model(&dto.BusLocation{}).
+		Where("timestamp >= ?", since).
+		Order("bus_id, timestamp").
+		Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	var (
+		currentBus uint
+		last       = visit{index: -1}
+		previous   time.Time
+	)
+
+	for rows.Next() {
+		location := dto.BusLocation{}
+		if err := s.shared.DB.ScanRows(rows, &location); err != nil {
+			return err
+		}
+
+		route := terminals[busRoute[location.BusID]]
+		path := paths[busRoute[location.BusID]]
+		if len(route) < 2 {
+			continue
+		}
+
+		if location.BusID != currentBus || location.Timestamp.Sub(previous) > dto.TRAVELTIMEMAXGAP {
+			currentBus = location.BusID
+			last = visit{index: -1}
+		}
+		previous = location.Timestamp
+
+		index := path.NearestStop(location.Lat, location.Long, dto.GeofenceRadius(s.shared.Env), nil)
+		if index < 0 || index == last.index {
+			continue
+		}
+
+		elapsed := location.Timestamp.Sub(last.at)
+		if last.index >= 0 && path.Next(last.index) == index && elapsed > 0 && elapsed <= dto.TRAVELTIMEMAXSEGMENT {
+			from, to := route[last.index].ID, route[index].ID
+			weekday := int(last.at.In(dto.WIB).Weekday())
+			for _, bucket := range []dto.SegmentBucket{
+				dto.NewSegmentBucket(from, to, weekday, last.at),
+				dto.NewSegmentBucket(from, to, dto.ALLWEEKDAY, last.at),
+			} {
+				samples[bucket] = append(samples[bucket], elapsed.Seconds())
+				segments[bucket] = busRoute[location.BusID]
+			}
+		}
+
+		last = visit{index: index, at: location.Timestamp}
+	}
+
+	model := make([]dto.SegmentTravelTime, 0, len(samples))
+	for bucket, values := range samples {
+		model = append(model, dto.SegmentTravelTime{
+			Route:          segments[bucket],
+			FromTerminalID: bucket.FromTerminalID,
+			ToTerminalID:   bucket.ToTerminalID,
+			Weekday:        bucket.Weekday,
+			Hour:           bucket.Hour,
+			Samples:        len(values),
+			Seconds:        median(values),
+			UpdatedAt:      time.Now(),
+		})
+	}
+
+	err = s.shared.DB.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("1 = 1").Delete(&dto.SegmentTravelTime{}).Error; err != nil {
+			return err
+		}
+		if len(model) == 0 {
+			return nil
+		}
+		return tx.CreateInBatches(model, 500).Error
+	})
+	if err != nil {
+		return err
+	}
+
+	s.setModel(model)
+
+	s.shared.Logger.Infof("segment travel time recomputed, bucket: %d", len(model))
+
+	return nil
+}
+
+/**
+ * Get travel time in second between two consecutive terminal
+ * Use the weekday and hour bucket, fallback to the hour bucket of every weekday
+ */
+func (s *service) SegmentTime(from uint, to uint, at time.Time) (float64, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	weekday := int(at.In(dto.WIB).Weekday())
+	for _, w := range []int{weekday, dto.ALLWEEKDAY} {
+		stat, ok := s.model[dto.NewSegmentBucket(from, to, w, at)]
+		if ok && stat.Samples >= dto.TRAVELTIMEMINSAMPLE {
+			return stat.Seconds, true
+		}
+	}
+
+	return 0, false
+}
+
+func (s *service) setModel(data []dto.SegmentTravelTime) {
+	model := make(map[dto.SegmentBucket]dto.SegmentTravelTime, len(data))
+	for _, d := range data {
+		model[d.Bucket()] = d
+	}
+
+	s.mu.Lock()
+	s.model = model
+	s.mu.Unlock()
+}
+
+func median(values []float64) float64 {
+	sort.Float64s(values)
+	mid := len(values) / 2
+	if len(values)%2 == 0 {
+		return (values[mid-1] + values[mid]) / 2
+	}
+	return values[mid]
+}
+
+func NewTravelTimeService(shared shared.Holder, bus bus.Service, route route.Service, terminal terminal.Service) Service {
+	return &service{
+		shared:   shared,
+		bus:      bus,
+		route:    route,
+		terminal: terminal,
+		model:    make(map[dto.SegmentBucket]dto.SegmentTravelTime),
+	}
+}
@@ -0,0 +1,521 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"os/signal"
+	"sort"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+	"tracking-server/shared/common"
+	"tracking-server/shared/dto"
+
+	"github.com/fasthttp/websocket"
+	"github.com/goccy/go-json"
+)
+
+/**
+ * Load generator for bus tracking stream
+ * Simulate driver connection sending location and rider connection consuming the stream
+ * Report latency from driver send to rider receive, error rate and throughput
+ *
+ * Inactive driver account only activated with -provision, every bus activated or created
+ * by the run deactivated again on exit and created bus deleted
+ *
+ * go run ./cmd/loadtest -host localhost:8000 -drivers 20 -riders 500 -duration 2m
+ */
+
+type (
+	config struct {
+		Host         string
+		Secure       bool
+		Drivers      int
+		Riders       int
+		Duration     time.Duration
+		Interval     time.Duration
+		Ramp         time.Duration
+		Prefix       string
+		Password     string
+		Provision    bool
+		NumberOffset int
+		Sandbox      string
+		SandboxToken string
+	}
+
+	driver struct {
+		ID        uint
+		Key       string
+		Token     string
+		Username  string
+		Activated bool
+		Created   bool
+	}
+
+	stats struct {
+		driverConnected int64
+		driverConnErr   int64
+		driverSent      int64
+		driverSendErr   int64
+		riderConnected  int64
+		riderConnErr    int64
+		riderReceived   int64
+		riderReadErr    int64
+		riderBytes      int64
+
+		mu         sync.Mutex
+		latency    []time.Duration
+		ackLatency []time.Duration
+	}
+)
+
+var (
+	// sent location keyed by bus and coordinate, value is the send time
+	sent sync.Map
+)
+
+func main() {
+	cfg := config{}
+	flag.StringVar(&cfg.Host, "host", "localhost:8000", "tracking server host")
+	flag.BoolVar(&cfg.Secure, "secure", false, "use https and wss")
+	flag.IntVar(&cfg.Drivers, "drivers", 10, "number of driver connection")
+	flag.IntVar(&cfg.Riders, "riders", 100, "number of rider connection")
+	flag.DurationVar(&cfg.Duration, "duration", time.Minute, "test duration")
+	flag.DurationVar(&cfg.Interval, "interval", time.Second, "driver send interval")
+	flag.DurationVar(&cfg.Ramp, "ramp", 10*time.Second, "time to open all connection")
+	flag.StringVar(&cfg.Prefix, "prefix", "loadtest", "driver username prefix, username is prefix followed by index")
+	flag.StringVar(&cfg.Password, "password", "loadtest", "driver password")
+	flag.BoolVar(&cfg.Provision, "provision", false, "create driver bus when login failed and activate inactive driver, reverted on exit")
+	flag.IntVar(&cfg.NumberOffset, "number-offset", 9000, "bus number for provisioned driver start from this offset")
+	flag.StringVar(&cfg.Sandbox, "sandbox", "", "run against experimental sandbox instead of real bus")
+	flag.StringVar(&cfg.SandboxToken, "sandbox-token", "", "sandbox token")
+	flag.Parse()
+
+	interrupted, stop := context.WithCancel(context.Background())
+	defer stop()
+
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-interrupt
+		// second interrupt kill the process while driver restored
+		signal.Stop(interrupt)
+		stop()
+	}()
+
+	drivers, err := prepareDrivers(interrupted, cfg)
+	if err != nil {
+		restoreDrivers(cfg, drivers)
+		log.Fatalf("error when preparing driver: %s", err.Error())
+	}
+
+	ctx, cancel := context.WithTimeout(interrupted, cfg.Duration)
+	defer cancel()
+
+	var (
+		s     = &stats{}
+		wg    sync.WaitGroup
+		total = len(drivers) + cfg.Riders
+		start = time.Now()
+	)
+
+	log.Printf("starting load test, drivers: %d, riders: %d, duration: %s", len(drivers), cfg.Riders, cfg.Duration)
+
+	go progress(ctx, s, start)
+
+	for i := 0; i < total; i++ {
+		wg.Add(1)
+		if i < len(drivers) {
+			go func(d driver) {
+				defer wg.Done()
+				runDriver(ctx, cfg, d, s)
+			}(drivers[i])
+		} else {
+			go func() {
+				defer wg.Done()
+				runRider(ctx, cfg, s)
+			}()
+		}
+
+		select {
+		case <-ctx.Done():
+		case <-time.After(cfg.Ramp / time.Duration(total)):
+		}
+	}
+
+	wg.Wait()
+
+	report(s, time.Since(start))
+	restoreDrivers(cfg, drivers)
+}
+
+/**
+ * Login every driver account, create and activate the bus only if provision allowed
+ * Driver prepared so far returned on error so the change can be reverted
+ * Sandbox mode only need experimental id
+ */
+func prepareDrivers(ctx context.Context, cfg config) ([]driver, error) {
+	drivers := make([]driver, 0, cfg.Drivers)
+
+	for i := 0; i < cfg.Drivers; i++ {
+		if ctx.Err() != nil {
+			return drivers, errors.New("interrupted")
+		}
+
+		username := fmt.Sprintf("%s%d", cfg.Prefix, i)
+
+		if cfg.Sandbox != "" {
+			drivers = append(drivers, driver{Key: username, Username: username})
+			continue
+		}
+
+		created := false
+		login, err := loginDriver(cfg, username)
+		if err != nil && cfg.Provision {
+			err = provisionDriver(cfg, i, username)
+			if err == nil {
+				created = true
+				login, err = loginDriver(cfg, username)
+			}
+		}
+		if err != nil {
+			if created {
+				log.Printf("driver %s created but login failed, remove it manually", username)
+			}
+			return drivers, fmt.Errorf("driver %s: %w", username, err)
+		}
+
+		d := driver{
+			ID:       login.ID,
+			Key:      strconv.FormatUint(uint64(login.ID), 10),
+			Token:    login.Token,
+			Username: username,
+			Created:  created,
+		}
+
+		if !login.IsActive {
+			if !cfg.Provision {
+				return drivers, fmt.Errorf("driver %s is inactive, rerun with -provision to activate it for the test", username)
+			}
+
+			err = setDriverActive(cfg, d, true)
+			if err != nil {
+				drivers = append(drivers, d)
+				return drivers, fmt.Errorf("activate driver %s: %w", username, err)
+			}
+			d.Activated = true
+		}
+
+		drivers = append(drivers, d)
+	}
+
+	return drivers, nil
+}
+
+/**
+ * Deactivate every bus activated or created by the run, created bus deleted afterwards
+ * Deletion failure only logged since the bus already out of the rider feed
+ */
+func restoreDrivers(cfg config, drivers []driver) {
+	for _, d := range drivers {
+		if !d.Activated && !d.Created {
+			continue
+		}
+
+		if err := setDriverActive(cfg, d, false); err != nil {
+			log.Printf("error when deactivating driver %s, bus %d still active: %s", d.Username, d.ID, err.Error())
+			continue
+		}
+
+		if !d.Created {
+			continue
+		}
+
+		path := fmt.Sprintf("/bus/%d", d.ID)
+		if err := doRequest(cfg, http.MethodDelete, path, "", nil, nil); err != nil {
+			log.Printf("error when deleting driver %s, bus %d left inactive: %s", d.Username, d.ID, err.Error())
+		}
+	}
+}
+
+func loginDriver(cfg config, username string) (dto.DriverLoginResponse, error) {
+	var response dto.DriverLoginResponse
+
+	err := doRequest(cfg, http.MethodPost, "/bus/loginAlt", "", dto.DriverLoginDto{
+		Username: username,
+		Password: cfg.Password,
+	}, &response)
+
+	return response, err
+}
+
+func provisionDriver(cfg config, index int, username string) error {
+	route := dto.RED
+	if index%2 == 1 {
+		route = dto.BLUE
+	}
+
+	return doRequest(cfg, http.MethodPost, "/bus/", "", dto.CreateBusDto{
+		Number:   cfg.NumberOffset + index,
+		Plate:    fmt.Sprintf("LOAD %d", index),
+		Route:    route,
+		Username: username,
+		Password: cfg.Password,
+	}, nil)
+}
+
+func setDriverActive(cfg config, d driver, active bool) error {
+	path := fmt.Sprintf("/bus/%d", d.ID)
+	return doRequest(cfg, http.MethodPut, path, d.Token, dto.EditBusDto{IsActive: active}, nil)
+}
+
+func doRequest(cfg config, method string, path string, auth string, body interface{}, data interface{}) error {
+	scheme := "http"
+	if cfg.Secure {
+		scheme = "https"
+	}
+
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(method, scheme+"://"+cfg.Host+path, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if auth != "" {
+		req.Header.Set("auth", auth)
+	}
+
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	response := common.Response{Data: data}
+	err = json.NewDecoder(res.Body).Decode(&response)
+	if err != nil {
+		return err
+	}
+
+	if response.Status != "OK" {
+		return errors.New(response.Error)
+	}
+
+	return nil
+}
+
+func streamURL(cfg config, query url.Values) string {
+	scheme := "ws"
+	if cfg.Secure {
+		scheme = "wss"
+	}
+
+	if cfg.Sandbox != "" {
+		query.Set("experimental", "true")
+		query.Set("sandbox", cfg.Sandbox)
+		query.Set("sandboxToken", cfg.SandboxToken)
+	}
+
+	return fmt.Sprintf("%s://%s/bus/stream?%s", scheme, cfg.Host, query.Encode())
+}
+
+/**
+ * Send location every interval, every location unique so rider can match it
+ */
+func runDriver(ctx context.Context, cfg config, d driver, s *stats) {
+	query := url.Values{}
+	query.Set("type", string(dto.DRIVER))
+	query.Set("token", d.Token)
+	if cfg.Sandbox != "" {
+		query.Set("experimentalId", d.Key)
+	}
+
+	conn, _, err := websocket.DefaultDialer.DialContext(ctx, streamURL(cfg, query), nil)
+	if err != nil {
+		atomic.AddInt64(&s.driverConnErr, 1)
+		return
+	}
+	defer conn.Close()
+	atomic.AddInt64(&s.driverConnected, 1)
+
+	go func() {
+		<-ctx.Done()
+		conn.Close()
+	}()
+
+	ticker := time.NewTicker(cfg.Interval)
+	defer ticker.Stop()
+
+	for seq := 0; ; seq++ {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		message := dto.BusLocationMessage{
+			Lat:     -6.3600 - float64(seq%100000)*0.0000001,
+			Long:    106.8300 + float64(d.ID%1000)*0.00001,
+			Speed:   8,
+			Heading: float64(seq % 360),
+		}
+
+		now := time.Now()
+		sent.Store(locationKey(d.Key, message.Lat, message.Long), now)
+
+		if err := conn.WriteJSON(message); err != nil {
+			atomic.AddInt64(&s.driverSendErr, 1)
+			return
+		}
+
+		ack := dto.BusLocationMessage{}
+		if err := conn.ReadJSON(&ack); err != nil {
+			atomic.AddInt64(&s.driverSendErr, 1)
+			return
+		}
+
+		atomic.AddInt64(&s.driverSent, 1)
+		s.mu.Lock()
+		s.ackLatency = append(s.ackLatency, time.Since(now))
+		s.mu.Unlock()
+	}
+}
+
+/**
+ * Consume the stream, first time a sent location seen by this rider counted as latency sample
+ */
+func runRider(ctx context.Context, cfg config, s *stats) {
+	conn, _, err := websocket.DefaultDialer.DialContext(ctx, streamURL(cfg, url.Values{}), nil)
+	if err != nil {
+		atomic.AddInt64(&s.riderConnErr, 1)
+		return
+	}
+	defer conn.Close()
+	atomic.AddInt64(&s.riderConnected, 1)
+
+	go func() {
+		<-ctx.Done()
+		conn.Close()
+	}()
+
+	seen := make(map[string]bool)
+
+	for {
+		_, raw, err := conn.ReadMessage()
+		if err != nil {
+			if ctx.Err() == nil {
+				atomic.AddInt64(&s.riderReadErr, 1)
+			}
+			return
+		}
+		received := time.Now()
+
+		atomic.AddInt64(&s.riderReceived, 1)
+		atomic.AddInt64(&s.riderBytes, int64(len(raw)))
+
+		locations := []dto.TrackLocationResponse{}
+		if err := json.Unmarshal(raw, &locations); err != nil {
+			atomic.AddInt64(&s.riderReadErr, 1)
+			continue
+		}
+
+		for _, l := range locations {
+			id := strconv.FormatUint(uint64(l.ID), 10)
+			if cfg.Sandbox != "" {
+				id = l.Plate
+			}
+
+			key := locationKey(id, l.Lat, l.Long)
+			if seen[key] {
+				continue
+			}
+
+			value, ok := sent.Load(key)
+			if !ok {
+				continue
+			}
+			seen[key] = true
+
+			s.mu.Lock()
+			s.latency = append(s.latency, received.Sub(value.(time.Time)))
+			s.mu.Unlock()
+		}
+	}
+}
+
+func locationKey(id string, lat float64, long float64) string {
+	return fmt.Sprintf("%s:%.7f:%.7f", id, lat, long)
+}
+
+func progress(ctx context.Context, s *stats, start time.Time) {
+	ticker := time.NewTicker(5 * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			log.Printf(
+				"elapsed: %s, drivers: %d, riders: %d, sent: %d, received: %d",
+				time.Since(start).Round(time.Second),
+				atomic.LoadInt64(&s.driverConnected),
+				atomic.LoadInt64(&s.riderConnected),
+				atomic.LoadInt64(&s.driverSent),
+				atomic.LoadInt64(&s.riderReceived),
+			)
+		}
+	}
+}
+
+func report(s *stats, elapsed time.Duration) {
+	seconds := elapsed.Seconds()
+
+	fmt.Println()
+	fmt.Printf("duration                 %s\n", elapsed.Round(time.Millisecond))
+	fmt.Printf("driver connected         %d (connect error %d)\n", s.driverConnected, s.driverConnErr)
+	fmt.Printf("driver sent              %d (%.1f msg/s, send error %d)\n", s.driverSent, float64(s.driverSent)/seconds, s.driverSendErr)
+	fmt.Printf("rider connected          %d (connect error %d)\n", s.riderConnected, s.riderConnErr)
+	fmt.Printf("rider received           %d (%.1f msg/s, %.1f KB/s, read error %d)\n",
+		s.riderReceived, float64(s.riderReceived)/seconds, float64(s.riderBytes)/1024/seconds, s.riderReadErr)
+	fmt.Printf("connection error rate    %.2f%%\n", percentage(s.driverConnErr+s.riderConnErr, s.driverConnErr+s.riderConnErr+s.driverConnected+s.riderConnected))
+	fmt.Printf("message error rate       %.2f%%\n", percentage(s.driverSendErr+s.riderReadErr, s.driverSendErr+s.riderReadErr+s.driverSent+s.riderReceived))
+	fmt.Printf("driver ack latency       %s\n", summarize(s.ackLatency))
+	fmt.Printf("driver to rider latency  %s\n", summarize(s.latency))
+}
+
+func percentage(part int64, total int64) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(part) / float64(total) * 100
+}
+
+func summarize(samples []time.Duration) string {
+	if len(samples) == 0 {
+		return "no sample"
+	}
+
+	sort.Slice(samples, func(i, j int) bool {
+		return samples[i] < samples[j]
+	})
+
+	at := func(p float64) time.Duration {
+		return samples[int(p*float64(len(samples)-1))].Round(time.Millisecond)
+	}
+
+	return fmt.Sprintf("p50 %s, p90 %s, p99 %s, max %s (%d sample)", at(0.5), at(0.9), at(0.99), at(1), len(samples))
+}
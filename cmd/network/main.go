@@ -0,0 +1,74 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"tracking-server/application"
+	"tracking-server/di"
+)
+
+/**
+ * Import route network data file to the database or export the database back to it
+ * Format follow the file extension, .json for json and yaml otherwise
+ *
+ * go run ./cmd/network import -file data/network.yaml
+ * go run ./cmd/network import -file data/network.yaml -force
+ * go run ./cmd/network export -file data/network.yaml
+ */
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+	}
+
+	var (
+		command = os.Args[1]
+		flags   = flag.NewFlagSet(command, flag.ExitOnError)
+		file    = flags.String("file", "data/network.yaml", "network data file, yaml or json")
+		force   = flags.Bool("force", false, "import even when the file version is not newer than the last import")
+	)
+
+	if err := flags.Parse(os.Args[2:]); err != nil {
+		log.Fatal(err.Error())
+	}
+
+	err := di.Container.Invoke(func(app application.Holder) error {
+		switch command {
+		case "import":
+			applied, err := app.NetworkService.Load(*file, *force)
+			if err != nil {
+				return err
+			}
+			if !applied {
+				log.Printf("network version in %s already imported, use -force to import again", *file)
+				return nil
+			}
+			log.Printf("network imported from %s", *file)
+
+		case "export":
+			network, err := app.NetworkService.Export()
+			if err != nil {
+				return err
+			}
+			if err := app.NetworkService.Write(*file, network); err != nil {
+				return err
+			}
+			log.Printf("network version %d exported to %s, %d route, %d terminal", network.Version, *file, len(network.Routes), len(network.Terminals))
+
+		default:
+			usage()
+		}
+		return nil
+	})
+
+	if err != nil {
+		log.Fatalf("error when running network %s: %s", command, err.Error())
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: network <import|export> [-file path] [-force]")
+	os.Exit(2)
+}
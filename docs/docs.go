@@ -52,7 +52,7 @@ const docTemplate = `{
         },
         "/bus/info/{id}": {
             "post": {
-                "description": "Put all mandatory parameter",
+                "description": "Closed terminal return the closure reason without bus estimation",
                 "consumes": [
                     "application/json"
                 ],
@@ -70,6 +70,16 @@ const docTemplate = `{
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "enum": [
+                            "id",
+                            "en"
+                        ],
+                        "type": "string",
+                        "description": "response language",
+                        "name": "lang",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -116,6 +126,72 @@ const docTemplate = `{
                 }
             }
         },
+        "/bus/loginAlt": {
+            "post": {
+                "description": "Put all mandatory parameter",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Bus"
+                ],
+                "summary": "Alternative Driver login",
+                "parameters": [
+                    {
+                        "description": "DriverLoginDto",
+                        "name": "DriverLoginDto",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.DriverLoginDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.DriverLoginResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/bus/upcoming/{id}": {
+            "get": {
+                "description": "Every upcoming terminal in route order with arrival estimation",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Bus"
+                ],
+                "summary": "Get upcoming terminal of a bus",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Bus ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.UpcomingStopResponse"
+                        }
+                    }
+                }
+            }
+        },
         "/bus/{id}": {
             "put": {
                 "description": "Put all mandatory parameter",
@@ -187,56 +263,41 @@ const docTemplate = `{
                 "responses": {}
             }
         },
-        "/healthcheck": {
+        "/closure/": {
             "get": {
                 "description": "Put all mandatory parameter",
-                "consumes": [
-                    "application/json"
-                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Healthcheck"
+                    "Closure"
                 ],
-                "summary": "Check system status",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/dto.Status"
-                            }
-                        }
+                "summary": "Get all terminal closure and detour",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "route code",
+                        "name": "route",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "only closure in effect or upcoming",
+                        "name": "active",
+                        "in": "query"
                     }
-                }
-            }
-        },
-        "/news/": {
-            "get": {
-                "description": "Put all mandatory parameter",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "News"
                 ],
-                "summary": "Get all news",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.GetAllNewsResponse"
+                            "$ref": "#/definitions/dto.GetAllClosureResponse"
                         }
                     }
                 }
             },
             "post": {
-                "description": "Put all mandatory parameter",
+                "description": "Closed terminal not served by the route between start and end time, detour carry the alternative path",
                 "consumes": [
                     "application/json"
                 ],
@@ -244,17 +305,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "News"
+                    "Closure"
                 ],
-                "summary": "Create new news",
+                "summary": "Create terminal closure or detour",
                 "parameters": [
                     {
-                        "description": "CreateNews",
-                        "name": "CreateNewsDto",
+                        "description": "CreateClosure",
+                        "name": "CreateClosureDto",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.CreateNewsDto"
+                            "$ref": "#/definitions/dto.CreateClosureDto"
                         }
                     }
                 ],
@@ -262,29 +323,26 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.CreateBusResponse"
+                            "$ref": "#/definitions/dto.ClosureResponse"
                         }
                     }
                 }
             }
         },
-        "/news/{id}": {
+        "/closure/{id}": {
             "get": {
                 "description": "Put all mandatory parameter",
-                "consumes": [
-                    "application/json"
-                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "News"
+                    "Closure"
                 ],
-                "summary": "Get news detail",
+                "summary": "Get closure detail",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "News ID",
+                        "description": "closure id",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -294,7 +352,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.News"
+                            "$ref": "#/definitions/dto.ClosureResponse"
                         }
                     }
                 }
@@ -308,24 +366,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "News"
+                    "Closure"
                 ],
-                "summary": "Edit news",
+                "summary": "Edit closure",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "News ID",
+                        "description": "closure id",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "EditNewsDto",
-                        "name": "EditNewsDto",
+                        "description": "EditClosure",
+                        "name": "EditClosureDto",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.EditNewsDto"
+                            "$ref": "#/definitions/dto.EditClosureDto"
                         }
                     }
                 ],
@@ -333,7 +391,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.News"
+                            "$ref": "#/definitions/dto.ClosureResponse"
                         }
                     }
                 }
@@ -347,13 +405,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "News"
+                    "Closure"
                 ],
-                "summary": "Delete News",
+                "summary": "Delete closure",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "News ID",
+                        "description": "closure id",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -362,75 +420,210 @@ const docTemplate = `{
                 "responses": {}
             }
         },
-        "/terminal/allTerminal": {
-            "post": {
-                "description": "Put all mandatory parameter",
-                "consumes": [
-                    "application/json"
-                ],
+        "/crowd/predict/{id}": {
+            "get": {
+                "description": "Time window start at the given time in RFC3339, default now",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Terminal"
+                    "Crowd"
                 ],
-                "summary": "Get all terminal sorted by distance",
+                "summary": "Predict crowd level at a terminal",
                 "parameters": [
                     {
-                        "description": "GetAllTerminalDto",
-                        "name": "GetAllTerminalDto",
-                        "in": "body",
-                        "required": true,
+                        "type": "string",
+                        "description": "terminal ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "window start",
+                        "name": "at",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "window in minute, default 60",
+                        "name": "window",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "history in day, default 28",
+                        "name": "days",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.GetAllTerminalDto"
+                            "$ref": "#/definitions/dto.PredictCrowdResponse"
                         }
                     }
+                }
+            }
+        },
+        "/crowd/statistic": {
+            "get": {
+                "description": "Share of time spent on each crowd status for every hour of the day in WIB",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Crowd"
+                ],
+                "summary": "Get crowding statistic per route and hour",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "route code",
+                        "name": "route",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "history in day, default 28",
+                        "name": "days",
+                        "in": "query"
+                    }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.GetAllTerminalResponse"
+                            "$ref": "#/definitions/dto.GetCrowdStatisticResponse"
                         }
                     }
                 }
             }
         },
-        "/terminal/twoClosest": {
-            "post": {
-                "description": "Put all mandatory parameter",
-                "consumes": [
+        "/geofence/event": {
+            "get": {
+                "description": "Filter is optional, from and to in RFC3339 format",
+                "produces": [
                     "application/json"
                 ],
+                "tags": [
+                    "Geofence"
+                ],
+                "summary": "Get terminal arrival and departure event",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "bus ID",
+                        "name": "busId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "terminal ID",
+                        "name": "terminalId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "route code",
+                        "name": "route",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "ARRIVAL",
+                            "DEPARTURE"
+                        ],
+                        "type": "string",
+                        "description": "event type",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "start time",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "end time",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "max event, default 100",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.GetTerminalEventResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/headway/": {
+            "get": {
+                "description": "Headway in minute to the bus ahead, status is NORMAL, BUNCHING or GAP",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Terminal"
+                    "Headway"
                 ],
-                "summary": "Get two closestterminal",
+                "summary": "Get headway between consecutive bus of each route",
                 "parameters": [
                     {
-                        "description": "GetAllTerminalDto",
-                        "name": "GetAllTerminalDto",
-                        "in": "body",
-                        "required": true,
+                        "type": "string",
+                        "description": "route code",
+                        "name": "route",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.GetAllTerminalDto"
+                            "$ref": "#/definitions/dto.GetHeadwayResponse"
                         }
                     }
+                }
+            }
+        },
+        "/healthcheck": {
+            "get": {
+                "description": "Put all mandatory parameter",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Healthcheck"
                 ],
+                "summary": "Check system status",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.GetAllTerminalResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.Status"
+                            }
                         }
                     }
                 }
             }
         },
-        "/terminal/{id}": {
+        "/news/": {
             "get": {
                 "description": "Put all mandatory parameter",
                 "consumes": [
@@ -440,369 +633,3474 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Terminal"
+                    "News"
                 ],
-                "summary": "Get terminal info",
+                "summary": "Get all news",
                 "parameters": [
                     {
+                        "enum": [
+                            "id",
+                            "en"
+                        ],
                         "type": "string",
-                        "description": "terminal ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "response language",
+                        "name": "lang",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.GetTerminalInfoResponse"
+                            "$ref": "#/definitions/dto.GetAllNewsResponse"
                         }
                     }
                 }
-            }
+            },
+            "post": {
+                "description": "Put all mandatory parameter",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "News"
+                ],
+                "summary": "Create new news",
+                "parameters": [
+                    {
+                        "description": "CreateNews",
+                        "name": "CreateNewsDto",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateNewsDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateBusResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/news/{id}": {
+            "get": {
+                "description": "Put all mandatory parameter",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "News"
+                ],
+                "summary": "Get news detail",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "News ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "id",
+                            "en"
+                        ],
+                        "type": "string",
+                        "description": "response language",
+                        "name": "lang",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.News"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Put all mandatory parameter",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "News"
+                ],
+                "summary": "Edit news",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "News ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "EditNewsDto",
+                        "name": "EditNewsDto",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.EditNewsDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.News"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Put all mandatory parameter",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "News"
+                ],
+                "summary": "Delete News",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "News ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {}
+            }
+        },
+        "/place/search": {
+            "get": {
+                "description": "Rider location is optional, used to pick the boarding terminal of each route",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Place"
+                ],
+                "summary": "Search place by name or alias",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "place name or alias",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "number",
+                        "description": "rider latitude",
+                        "name": "lat",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "rider longitude",
+                        "name": "long",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "max place returned",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "id",
+                            "en"
+                        ],
+                        "type": "string",
+                        "description": "response language",
+                        "name": "lang",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.SearchPlaceResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/place/{id}": {
+            "get": {
+                "description": "Put all mandatory parameter",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Place"
+                ],
+                "summary": "Get place detail",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "place ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "id",
+                            "en"
+                        ],
+                        "type": "string",
+                        "description": "response language",
+                        "name": "lang",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.PlaceResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/report/": {
+            "post": {
+                "description": "Status required for CROWD, terminalId for SKIPPED_STOP and message for OTHER",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Report"
+                ],
+                "summary": "Submit rider report of a bus",
+                "parameters": [
+                    {
+                        "description": "CreateReport",
+                        "name": "CreateReportDto",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateReportDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateReportResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/report/review": {
+            "get": {
+                "description": "Report with free text message, default PENDING oldest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Report"
+                ],
+                "summary": "Get rider report review queue",
+                "parameters": [
+                    {
+                        "enum": [
+                            "PENDING",
+                            "REVIEWED",
+                            "DISMISSED"
+                        ],
+                        "type": "string",
+                        "description": "review status",
+                        "name": "review",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "bus ID",
+                        "name": "busId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "max report, default 50",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.GetReviewQueueResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/report/review/{id}": {
+            "put": {
+                "description": "Put all mandatory parameter",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Report"
+                ],
+                "summary": "Review rider report",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "report ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "ReviewReport",
+                        "name": "ReviewReportDto",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ReviewReportDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.RiderReport"
+                        }
+                    }
+                }
+            }
+        },
+        "/route/": {
+            "get": {
+                "description": "Put all mandatory parameter",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Route"
+                ],
+                "summary": "Get all route",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "only active route",
+                        "name": "active",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.GetAllRouteResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Code is uppercase alphanumeric, referenced by bus and terminal",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Route"
+                ],
+                "summary": "Create new route",
+                "parameters": [
+                    {
+                        "description": "CreateRoute",
+                        "name": "CreateRouteDto",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateRouteDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.BusRoute"
+                        }
+                    }
+                }
+            }
+        },
+        "/route/{code}": {
+            "get": {
+                "description": "Put all mandatory parameter",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Route"
+                ],
+                "summary": "Get route detail",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "route code",
+                        "name": "code",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.BusRoute"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Put all mandatory parameter",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Route"
+                ],
+                "summary": "Edit route",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "route code",
+                        "name": "code",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "EditRoute",
+                        "name": "EditRouteDto",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.EditRouteDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.BusRoute"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Route still used by bus or terminal can not be deleted",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Route"
+                ],
+                "summary": "Delete route",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "route code",
+                        "name": "code",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {}
+            }
+        },
+        "/route/{code}/shape": {
+            "get": {
+                "description": "Shape served as encoded polyline and GeoJSON feature, format limit to one of them",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Route"
+                ],
+                "summary": "Get route shape",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "route code",
+                        "name": "code",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "polyline",
+                            "geojson"
+                        ],
+                        "type": "string",
+                        "description": "shape format",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.GetRouteShapeResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Put encoded polyline or GeoJSON LineString, replace the existing shape",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Route"
+                ],
+                "summary": "Import route shape",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "route code",
+                        "name": "code",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "ImportShape",
+                        "name": "ImportShapeDto",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ImportShapeDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.GetRouteShapeResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Put all mandatory parameter",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Route"
+                ],
+                "summary": "Delete route shape",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "route code",
+                        "name": "code",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {}
+            }
+        },
+        "/sandbox/": {
+            "post": {
+                "description": "Token only returned once, use it as sandboxToken on stream",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Sandbox"
+                ],
+                "summary": "Create new sandbox for experimental tracking",
+                "parameters": [
+                    {
+                        "description": "CreateSandboxDto",
+                        "name": "CreateSandboxDto",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateSandboxDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateSandboxResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/sandbox/{name}": {
+            "delete": {
+                "description": "Put all mandatory parameter",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Sandbox"
+                ],
+                "summary": "Delete sandbox",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Sandbox name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "sandbox token",
+                        "name": "auth",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {}
+            }
+        },
+        "/sandbox/{name}/bus": {
+            "get": {
+                "description": "Put all mandatory parameter",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Sandbox"
+                ],
+                "summary": "Get bus linked in sandbox",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Sandbox name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "sandbox token",
+                        "name": "auth",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.SandboxBusResponse"
+                            }
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Put all mandatory parameter",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Sandbox"
+                ],
+                "summary": "Link experimental bus to bus metadata",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Sandbox name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "sandbox token",
+                        "name": "auth",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "LinkSandboxBusDto",
+                        "name": "LinkSandboxBusDto",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.LinkSandboxBusDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.SandboxBusResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/sandbox/{name}/bus/{experimentalId}": {
+            "delete": {
+                "description": "Put all mandatory parameter",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Sandbox"
+                ],
+                "summary": "Unlink experimental bus",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Sandbox name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Experimental bus ID",
+                        "name": "experimentalId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "sandbox token",
+                        "name": "auth",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {}
+            }
+        },
+        "/schedule/": {
+            "get": {
+                "description": "Put all mandatory parameter",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Schedule"
+                ],
+                "summary": "Get operating window and service exception",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.GetScheduleResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/schedule/exception": {
+            "post": {
+                "description": "Holiday stop the service, semester break use BREAK window, empty route applies to every route",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Schedule"
+                ],
+                "summary": "Create service exception",
+                "parameters": [
+                    {
+                        "description": "CreateServiceException",
+                        "name": "CreateServiceExceptionDto",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateServiceExceptionDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ServiceException"
+                        }
+                    }
+                }
+            }
+        },
+        "/schedule/exception/{id}": {
+            "delete": {
+                "description": "Put all mandatory parameter",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Schedule"
+                ],
+                "summary": "Delete service exception",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "exception ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {}
+            }
+        },
+        "/schedule/status": {
+            "get": {
+                "description": "Whether each route is operating now and when it next starts",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Schedule"
+                ],
+                "summary": "Get service status of every route",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.GetServiceStatusResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/schedule/window": {
+            "post": {
+                "description": "Start and end in HH:MM WIB, BREAK window used during semester break",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Schedule"
+                ],
+                "summary": "Create operating window",
+                "parameters": [
+                    {
+                        "description": "CreateOperatingWindow",
+                        "name": "CreateOperatingWindowDto",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateOperatingWindowDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.OperatingWindow"
+                        }
+                    }
+                }
+            }
+        },
+        "/schedule/window/{id}": {
+            "delete": {
+                "description": "Put all mandatory parameter",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Schedule"
+                ],
+                "summary": "Delete operating window",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "window ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {}
+            }
+        },
+        "/search/": {
+            "get": {
+                "description": "Prefix, alias and typo tolerant match, rider location rank nearby result higher",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Search"
+                ],
+                "summary": "Search terminal, place and news",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "search text",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "terminal",
+                            "place",
+                            "news"
+                        ],
+                        "type": "string",
+                        "description": "result type",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "rider latitude",
+                        "name": "lat",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "rider longitude",
+                        "name": "long",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "max result returned",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "id",
+                            "en"
+                        ],
+                        "type": "string",
+                        "description": "response language",
+                        "name": "lang",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.SearchResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/station/": {
+            "get": {
+                "description": "Terminal sharing the same name on different route grouped into one station",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Station"
+                ],
+                "summary": "Get all station",
+                "parameters": [
+                    {
+                        "type": "number",
+                        "description": "rider latitude",
+                        "name": "lat",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "rider longitude",
+                        "name": "long",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "max station returned",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "id",
+                            "en"
+                        ],
+                        "type": "string",
+                        "description": "response language",
+                        "name": "lang",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.GetAllStationResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/station/{id}": {
+            "get": {
+                "description": "Stop of each route with the bus arriving at each stop",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Station"
+                ],
+                "summary": "Get station detail",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "station id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "id",
+                            "en"
+                        ],
+                        "type": "string",
+                        "description": "response language",
+                        "name": "lang",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.StationResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/station/{id}/arrival": {
+            "get": {
+                "description": "Next bus of every route stopping at the station sorted by estimate",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Station"
+                ],
+                "summary": "Get combined arrival of a station",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "station id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "id",
+                            "en"
+                        ],
+                        "type": "string",
+                        "description": "response language",
+                        "name": "lang",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.StationArrivalResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/subscription/": {
+            "post": {
+                "description": "Notify once when a bus is within threshold minute of the terminal, recipient required for WEBHOOK channel",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Subscription"
+                ],
+                "summary": "Subscribe to bus arrival alert",
+                "parameters": [
+                    {
+                        "description": "CreateSubscription",
+                        "name": "CreateSubscriptionDto",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateSubscriptionDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateSubscriptionResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/subscription/{key}": {
+            "delete": {
+                "description": "Put all mandatory parameter",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Subscription"
+                ],
+                "summary": "Cancel bus arrival alert",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "subscription key",
+                        "name": "key",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {}
+            }
+        },
+        "/terminal/": {
+            "post": {
+                "description": "Empty sequence append the terminal at the end of the route",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Terminal"
+                ],
+                "summary": "Create new terminal",
+                "parameters": [
+                    {
+                        "description": "CreateTerminal",
+                        "name": "CreateTerminalDto",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateTerminalDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.TerminalResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/terminal/allTerminal": {
+            "post": {
+                "description": "Deprecated, use GET /terminal/nearest instead",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Terminal"
+                ],
+                "summary": "Get all terminal sorted by distance",
+                "deprecated": true,
+                "parameters": [
+                    {
+                        "description": "GetAllTerminalDto",
+                        "name": "GetAllTerminalDto",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.GetAllTerminalDto"
+                        }
+                    },
+                    {
+                        "enum": [
+                            "id",
+                            "en"
+                        ],
+                        "type": "string",
+                        "description": "response language",
+                        "name": "lang",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.GetAllTerminalResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/terminal/nearest": {
+            "get": {
+                "description": "Terminal sorted by distance with walking estimate, next terminal follow each route",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Terminal"
+                ],
+                "summary": "Get nearest terminal",
+                "parameters": [
+                    {
+                        "type": "number",
+                        "description": "rider latitude",
+                        "name": "lat",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "number",
+                        "description": "rider longitude",
+                        "name": "long",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "number",
+                        "description": "max distance in km",
+                        "name": "radius",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "route code",
+                        "name": "route",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "max terminal returned",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "id",
+                            "en"
+                        ],
+                        "type": "string",
+                        "description": "response language",
+                        "name": "lang",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.GetAllTerminalResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/terminal/reorder": {
+            "put": {
+                "description": "Terminal ids must contain every terminal of the route in the new order",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Terminal"
+                ],
+                "summary": "Reorder terminal of a route",
+                "parameters": [
+                    {
+                        "description": "ReorderTerminal",
+                        "name": "ReorderTerminalDto",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ReorderTerminalDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.GetRouteTerminalResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/terminal/route/{code}": {
+            "get": {
+                "description": "Put all mandatory parameter",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Terminal"
+                ],
+                "summary": "Get terminal of a route ordered by sequence",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "route code",
+                        "name": "code",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "id",
+                            "en"
+                        ],
+                        "type": "string",
+                        "description": "response language",
+                        "name": "lang",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.GetRouteTerminalResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/terminal/twoClosest": {
+            "post": {
+                "description": "Deprecated, use GET /terminal/nearest with limit instead",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Terminal"
+                ],
+                "summary": "Get two closest terminal",
+                "deprecated": true,
+                "parameters": [
+                    {
+                        "description": "GetAllTerminalDto",
+                        "name": "GetAllTerminalDto",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.GetAllTerminalDto"
+                        }
+                    },
+                    {
+                        "enum": [
+                            "id",
+                            "en"
+                        ],
+                        "type": "string",
+                        "description": "response language",
+                        "name": "lang",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.GetAllTerminalResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/terminal/{id}": {
+            "get": {
+                "description": "Put all mandatory parameter",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Terminal"
+                ],
+                "summary": "Get terminal info",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "terminal ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "id",
+                            "en"
+                        ],
+                        "type": "string",
+                        "description": "response language",
+                        "name": "lang",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.GetTerminalInfoResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Put all mandatory parameter",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Terminal"
+                ],
+                "summary": "Edit terminal",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "terminal ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "EditTerminal",
+                        "name": "EditTerminalDto",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.EditTerminalDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.TerminalResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Terminal after it on the route moved forward",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Terminal"
+                ],
+                "summary": "Delete terminal",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "terminal ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {}
+            }
+        },
+        "/trip/plan": {
+            "post": {
+                "description": "Put origin coordinate and either terminalId or destination name",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Trip"
+                ],
+                "summary": "Plan trip to a terminal or landmark",
+                "parameters": [
+                    {
+                        "description": "PlanTripDto",
+                        "name": "PlanTripDto",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.PlanTripDto"
+                        }
+                    },
+                    {
+                        "enum": [
+                            "id",
+                            "en"
+                        ],
+                        "type": "string",
+                        "description": "response language",
+                        "name": "lang",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.PlanTripResponse"
+                        }
+                    }
+                }
+            }
         }
     },
     "definitions": {
         "dto.BusInfo": {
             "type": "object",
             "properties": {
-                "estimate": {
-                    "type": "integer"
+                "estimate": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "nextTerminal": {
+                    "type": "string"
+                },
+                "number": {
+                    "type": "integer"
+                },
+                "plate": {
+                    "type": "string"
+                },
+                "route": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "stopsAway": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.BusInfoResponse": {
+            "type": "object",
+            "properties": {
+                "bus": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.BusInfo"
+                    }
+                },
+                "closure": {
+                    "$ref": "#/definitions/dto.ClosureInfo"
+                },
+                "service": {
+                    "$ref": "#/definitions/dto.RouteServiceStatus"
+                }
+            }
+        },
+        "dto.BusRoute": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "color": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "direction": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "isActive": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ClosedTerminal": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ClosureInfo": {
+            "type": "object",
+            "properties": {
+                "endAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "startAt": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ClosureResponse": {
+            "type": "object",
+            "properties": {
+                "detour": {
+                    "$ref": "#/definitions/dto.DetourResponse"
+                },
+                "endAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "inEffect": {
+                    "type": "boolean"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "reasonEn": {
+                    "type": "string"
+                },
+                "route": {
+                    "type": "string"
+                },
+                "startAt": {
+                    "type": "string"
+                },
+                "terminals": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ClosedTerminal"
+                    }
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.CreateBusDto": {
+            "type": "object",
+            "required": [
+                "number",
+                "password",
+                "plate",
+                "route",
+                "username"
+            ],
+            "properties": {
+                "number": {
+                    "type": "integer"
+                },
+                "password": {
+                    "type": "string"
+                },
+                "plate": {
+                    "type": "string"
+                },
+                "route": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.CreateBusResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "isActive": {
+                    "type": "boolean"
+                },
+                "number": {
+                    "type": "integer"
+                },
+                "plate": {
+                    "type": "string"
+                },
+                "route": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.CreateClosureDto": {
+            "type": "object",
+            "required": [
+                "endAt",
+                "reason",
+                "route",
+                "startAt"
+            ],
+            "properties": {
+                "detour": {
+                    "$ref": "#/definitions/dto.ImportShapeDto"
+                },
+                "endAt": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "reasonEn": {
+                    "type": "string"
+                },
+                "route": {
+                    "type": "string"
+                },
+                "startAt": {
+                    "type": "string"
+                },
+                "terminalIds": {
+                    "type": "array",
+                    "uniqueItems": true,
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "dto.CreateNewsDto": {
+            "type": "object",
+            "required": [
+                "detail",
+                "title"
+            ],
+            "properties": {
+                "detail": {
+                    "type": "string"
+                },
+                "detailEn": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "titleEn": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.CreateNewsResponse": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "detail": {
+                    "type": "string"
+                },
+                "detailEn": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "titleEn": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.CreateOperatingWindowDto": {
+            "type": "object",
+            "required": [
+                "dayType",
+                "end",
+                "route",
+                "start"
+            ],
+            "properties": {
+                "dayType": {
+                    "type": "string",
+                    "enum": [
+                        "WEEKDAY",
+                        "SATURDAY",
+                        "SUNDAY",
+                        "BREAK"
+                    ]
+                },
+                "end": {
+                    "type": "string"
+                },
+                "route": {
+                    "type": "string"
+                },
+                "start": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.CreateReportDto": {
+            "type": "object",
+            "required": [
+                "busId",
+                "deviceId",
+                "type"
+            ],
+            "properties": {
+                "busId": {
+                    "type": "integer"
+                },
+                "deviceId": {
+                    "type": "string",
+                    "maxLength": 64
+                },
+                "message": {
+                    "type": "string",
+                    "maxLength": 280
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "EMPTY",
+                        "MODERATE",
+                        "FULL"
+                    ]
+                },
+                "terminalId": {
+                    "type": "integer"
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "CROWD",
+                        "SKIPPED_STOP",
+                        "AC_BROKEN",
+                        "OTHER"
+                    ]
+                }
+            }
+        },
+        "dto.CreateReportResponse": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.CreateRouteDto": {
+            "type": "object",
+            "required": [
+                "code",
+                "color",
+                "direction",
+                "name"
+            ],
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "maxLength": 16
+                },
+                "color": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "direction": {
+                    "type": "string",
+                    "enum": [
+                        "CLOCKWISE",
+                        "COUNTERCLOCKWISE"
+                    ]
+                },
+                "isActive": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.CreateSandboxDto": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.CreateSandboxResponse": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "token": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.CreateServiceExceptionDto": {
+            "type": "object",
+            "required": [
+                "endDate",
+                "startDate",
+                "type"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "endDate": {
+                    "type": "string"
+                },
+                "route": {
+                    "type": "string"
+                },
+                "startDate": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "HOLIDAY",
+                        "BREAK"
+                    ]
+                }
+            }
+        },
+        "dto.CreateSubscriptionDto": {
+            "type": "object",
+            "required": [
+                "channel",
+                "route",
+                "terminalId",
+                "threshold"
+            ],
+            "properties": {
+                "channel": {
+                    "type": "string",
+                    "enum": [
+                        "WEBSOCKET",
+                        "WEBHOOK"
+                    ]
+                },
+                "expiresIn": {
+                    "type": "integer",
+                    "maximum": 240,
+                    "minimum": 1
+                },
+                "recipient": {
+                    "type": "string"
+                },
+                "route": {
+                    "type": "string"
+                },
+                "terminalId": {
+                    "type": "integer"
+                },
+                "threshold": {
+                    "type": "integer",
+                    "maximum": 60,
+                    "minimum": 1
+                }
+            }
+        },
+        "dto.CreateSubscriptionResponse": {
+            "type": "object",
+            "properties": {
+                "channel": {
+                    "type": "string"
+                },
+                "expiresAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "key": {
+                    "type": "string"
+                },
+                "route": {
+                    "type": "string"
+                },
+                "terminalId": {
+                    "type": "integer"
+                },
+                "threshold": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.CreateTerminalDto": {
+            "type": "object",
+            "required": [
+                "lat",
+                "long",
+                "name",
+                "route"
+            ],
+            "properties": {
+                "lat": {
+                    "type": "number"
+                },
+                "long": {
+                    "type": "number"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "nameEn": {
+                    "type": "string"
+                },
+                "placeIds": {
+                    "type": "array",
+                    "uniqueItems": true,
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "route": {
+                    "type": "string"
+                },
+                "sequence": {
+                    "type": "integer",
+                    "minimum": 1
+                }
+            }
+        },
+        "dto.CrowdShare": {
+            "type": "object",
+            "properties": {
+                "empty": {
+                    "type": "number"
+                },
+                "full": {
+                    "type": "number"
+                },
+                "moderate": {
+                    "type": "number"
+                }
+            }
+        },
+        "dto.DetourResponse": {
+            "type": "object",
+            "properties": {
+                "closureId": {
+                    "type": "integer"
+                },
+                "length": {
+                    "type": "number"
+                },
+                "polyline": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.DriverLoginDto": {
+            "type": "object",
+            "required": [
+                "password",
+                "username"
+            ],
+            "properties": {
+                "password": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.DriverLoginResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "isActive": {
+                    "type": "boolean"
+                },
+                "number": {
+                    "type": "integer"
+                },
+                "plate": {
+                    "type": "string"
+                },
+                "route": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "token": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.EditBusDto": {
+            "type": "object",
+            "properties": {
+                "isActive": {
+                    "type": "boolean"
+                },
+                "number": {
+                    "type": "integer"
+                },
+                "plate": {
+                    "type": "string"
+                },
+                "route": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "EMPTY",
+                        "MODERATE",
+                        "FULL"
+                    ]
+                }
+            }
+        },
+        "dto.EditBusResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "isActive": {
+                    "type": "boolean"
+                },
+                "number": {
+                    "type": "integer"
+                },
+                "plate": {
+                    "type": "string"
+                },
+                "route": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.EditClosureDto": {
+            "type": "object",
+            "properties": {
+                "clearDetour": {
+                    "type": "boolean"
+                },
+                "detour": {
+                    "$ref": "#/definitions/dto.ImportShapeDto"
+                },
+                "endAt": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "reasonEn": {
+                    "type": "string"
+                },
+                "startAt": {
+                    "type": "string"
+                },
+                "terminalIds": {
+                    "type": "array",
+                    "uniqueItems": true,
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "dto.EditNewsDto": {
+            "type": "object",
+            "properties": {
+                "detail": {
+                    "type": "string"
+                },
+                "detailEn": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "titleEn": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.EditRouteDto": {
+            "type": "object",
+            "properties": {
+                "color": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "direction": {
+                    "type": "string",
+                    "enum": [
+                        "CLOCKWISE",
+                        "COUNTERCLOCKWISE"
+                    ]
+                },
+                "isActive": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.EditTerminalDto": {
+            "type": "object",
+            "properties": {
+                "lat": {
+                    "type": "number"
+                },
+                "long": {
+                    "type": "number"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "nameEn": {
+                    "type": "string"
+                },
+                "placeIds": {
+                    "type": "array",
+                    "uniqueItems": true,
+                    "items": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "dto.GeoJSONFeature": {
+            "type": "object",
+            "properties": {
+                "geometry": {
+                    "$ref": "#/definitions/dto.GeoJSONGeometry"
+                },
+                "properties": {
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.GeoJSONGeometry": {
+            "type": "object",
+            "properties": {
+                "coordinates": {
+                    "type": "array",
+                    "items": {
+                        "type": "number"
+                    }
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.GeoJSONObject": {
+            "type": "object",
+            "properties": {
+                "coordinates": {
+                    "type": "array",
+                    "items": {
+                        "type": "number"
+                    }
+                },
+                "features": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.GeoJSONFeature"
+                    }
+                },
+                "geometry": {
+                    "$ref": "#/definitions/dto.GeoJSONGeometry"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.GetAllClosureResponse": {
+            "type": "object",
+            "properties": {
+                "closures": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ClosureResponse"
+                    }
+                }
+            }
+        },
+        "dto.GetAllNewsResponse": {
+            "type": "object",
+            "properties": {
+                "news": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.News"
+                    }
+                }
+            }
+        },
+        "dto.GetAllRouteResponse": {
+            "type": "object",
+            "properties": {
+                "routes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.BusRoute"
+                    }
+                }
+            }
+        },
+        "dto.GetAllStationResponse": {
+            "type": "object",
+            "properties": {
+                "stations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.StationResponse"
+                    }
+                }
+            }
+        },
+        "dto.GetAllTerminalDto": {
+            "type": "object",
+            "required": [
+                "lat",
+                "long"
+            ],
+            "properties": {
+                "lat": {
+                    "type": "number"
+                },
+                "long": {
+                    "type": "number"
+                }
+            }
+        },
+        "dto.GetAllTerminalResponse": {
+            "type": "object",
+            "properties": {
+                "terminal": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.TerminalListWithDistance"
+                    }
+                }
+            }
+        },
+        "dto.GetCrowdStatisticResponse": {
+            "type": "object",
+            "properties": {
+                "routes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.RouteCrowdStatistic"
+                    }
+                }
+            }
+        },
+        "dto.GetHeadwayResponse": {
+            "type": "object",
+            "properties": {
+                "routes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.RouteHeadway"
+                    }
+                }
+            }
+        },
+        "dto.GetReviewQueueResponse": {
+            "type": "object",
+            "properties": {
+                "reports": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.RiderReport"
+                    }
+                }
+            }
+        },
+        "dto.GetRouteShapeResponse": {
+            "type": "object",
+            "properties": {
+                "detours": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.DetourResponse"
+                    }
+                },
+                "geojson": {
+                    "$ref": "#/definitions/dto.GeoJSONFeature"
+                },
+                "length": {
+                    "type": "number"
+                },
+                "points": {
+                    "type": "integer"
+                },
+                "polyline": {
+                    "type": "string"
+                },
+                "route": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.GetRouteTerminalResponse": {
+            "type": "object",
+            "properties": {
+                "route": {
+                    "type": "string"
+                },
+                "terminals": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.TerminalResponse"
+                    }
+                }
+            }
+        },
+        "dto.GetScheduleResponse": {
+            "type": "object",
+            "properties": {
+                "exceptions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ServiceException"
+                    }
+                },
+                "windows": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.OperatingWindow"
+                    }
+                }
+            }
+        },
+        "dto.GetServiceStatusResponse": {
+            "type": "object",
+            "properties": {
+                "routes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.RouteServiceStatus"
+                    }
+                }
+            }
+        },
+        "dto.GetTerminalEventResponse": {
+            "type": "object",
+            "properties": {
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.TerminalEvent"
+                    }
+                }
+            }
+        },
+        "dto.GetTerminalInfoResponse": {
+            "type": "object",
+            "properties": {
+                "closure": {
+                    "$ref": "#/definitions/dto.ClosureInfo"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "relatedPlace": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "relatedTerminal": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.VisitedTerminal"
+                    }
+                },
+                "route": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.Headway": {
+            "type": "object",
+            "properties": {
+                "busId": {
+                    "type": "integer"
+                },
+                "distance": {
+                    "type": "number"
+                },
+                "leaderId": {
+                    "type": "integer"
+                },
+                "leaderNumber": {
+                    "type": "integer"
+                },
+                "minute": {
+                    "type": "number"
+                },
+                "number": {
+                    "type": "integer"
+                },
+                "plate": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.HourlyCrowd": {
+            "type": "object",
+            "properties": {
+                "hour": {
+                    "type": "integer"
+                },
+                "level": {
+                    "type": "number"
+                },
+                "minutes": {
+                    "type": "number"
+                },
+                "share": {
+                    "$ref": "#/definitions/dto.CrowdShare"
+                }
+            }
+        },
+        "dto.ImportShapeDto": {
+            "type": "object",
+            "properties": {
+                "geojson": {
+                    "$ref": "#/definitions/dto.GeoJSONObject"
+                },
+                "polyline": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.LinkSandboxBusDto": {
+            "type": "object",
+            "required": [
+                "busId",
+                "experimentalId"
+            ],
+            "properties": {
+                "busId": {
+                    "type": "integer"
+                },
+                "experimentalId": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.News": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "detail": {
+                    "type": "string"
+                },
+                "detailEn": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "titleEn": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.OperatingWindow": {
+            "type": "object",
+            "properties": {
+                "dayType": {
+                    "type": "string"
+                },
+                "end": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "route": {
+                    "type": "string"
+                },
+                "start": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.PlaceResponse": {
+            "type": "object",
+            "properties": {
+                "aliases": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "category": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "lat": {
+                    "type": "number"
+                },
+                "long": {
+                    "type": "number"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "routes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.PlaceRoute"
+                    }
+                }
+            }
+        },
+        "dto.PlaceRoute": {
+            "type": "object",
+            "properties": {
+                "alighting": {
+                    "$ref": "#/definitions/dto.PlaceTerminal"
+                },
+                "boarding": {
+                    "$ref": "#/definitions/dto.PlaceTerminal"
+                },
+                "route": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.PlaceTerminal": {
+            "type": "object",
+            "properties": {
+                "distance": {
+                    "type": "number"
                 },
                 "id": {
                     "type": "integer"
                 },
-                "number": {
+                "name": {
+                    "type": "string"
+                },
+                "walkMinute": {
                     "type": "integer"
+                }
+            }
+        },
+        "dto.PlanTripDto": {
+            "type": "object",
+            "required": [
+                "lat",
+                "long"
+            ],
+            "properties": {
+                "destination": {
+                    "type": "string"
                 },
-                "plate": {
+                "lat": {
+                    "type": "number"
+                },
+                "long": {
+                    "type": "number"
+                },
+                "terminalId": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.PlanTripResponse": {
+            "type": "object",
+            "properties": {
+                "options": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.TripOption"
+                    }
+                }
+            }
+        },
+        "dto.PredictCrowdResponse": {
+            "type": "object",
+            "properties": {
+                "from": {
                     "type": "string"
                 },
+                "level": {
+                    "type": "number"
+                },
                 "route": {
                     "type": "string"
                 },
+                "samples": {
+                    "type": "integer"
+                },
+                "share": {
+                    "$ref": "#/definitions/dto.CrowdShare"
+                },
+                "source": {
+                    "type": "string"
+                },
                 "status": {
                     "type": "string"
+                },
+                "terminal": {
+                    "type": "string"
+                },
+                "terminalId": {
+                    "type": "integer"
+                },
+                "to": {
+                    "type": "string"
                 }
             }
         },
-        "dto.BusInfoResponse": {
+        "dto.ReorderTerminalDto": {
             "type": "object",
+            "required": [
+                "route",
+                "terminalIds"
+            ],
             "properties": {
-                "bus": {
+                "route": {
+                    "type": "string"
+                },
+                "terminalIds": {
                     "type": "array",
+                    "minItems": 1,
+                    "uniqueItems": true,
                     "items": {
-                        "$ref": "#/definitions/dto.BusInfo"
+                        "type": "integer"
                     }
                 }
             }
         },
-        "dto.CreateBusDto": {
+        "dto.ReviewReportDto": {
             "type": "object",
             "required": [
-                "number",
-                "password",
-                "plate",
-                "route",
-                "username"
+                "review"
             ],
             "properties": {
-                "number": {
+                "note": {
+                    "type": "string",
+                    "maxLength": 280
+                },
+                "review": {
+                    "type": "string",
+                    "enum": [
+                        "REVIEWED",
+                        "DISMISSED"
+                    ]
+                }
+            }
+        },
+        "dto.RiderReport": {
+            "type": "object",
+            "properties": {
+                "busId": {
                     "type": "integer"
                 },
-                "password": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "deviceId": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "message": {
+                    "type": "string"
+                },
+                "review": {
+                    "type": "string"
+                },
+                "reviewNote": {
+                    "type": "string"
+                },
+                "reviewedAt": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "terminalId": {
+                    "type": "integer"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.RouteCrowdStatistic": {
+            "type": "object",
+            "properties": {
+                "hours": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.HourlyCrowd"
+                    }
+                },
+                "route": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.RouteHeadway": {
+            "type": "object",
+            "properties": {
+                "buses": {
+                    "type": "integer"
+                },
+                "headways": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.Headway"
+                    }
+                },
+                "route": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.RouteServiceStatus": {
+            "type": "object",
+            "properties": {
+                "dayType": {
+                    "type": "string"
+                },
+                "inService": {
+                    "type": "boolean"
+                },
+                "nextStart": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "route": {
+                    "type": "string"
+                },
+                "scheduled": {
+                    "type": "boolean"
+                },
+                "until": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.SandboxBusResponse": {
+            "type": "object",
+            "properties": {
+                "busId": {
+                    "type": "integer"
+                },
+                "experimentalId": {
                     "type": "string"
                 },
+                "number": {
+                    "type": "integer"
+                },
                 "plate": {
                     "type": "string"
                 },
                 "route": {
-                    "type": "string",
-                    "enum": [
-                        "RED",
-                        "BLUE"
-                    ]
+                    "type": "string"
                 },
-                "username": {
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.SearchPlaceResponse": {
+            "type": "object",
+            "properties": {
+                "places": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.PlaceResponse"
+                    }
+                }
+            }
+        },
+        "dto.SearchResponse": {
+            "type": "object",
+            "properties": {
+                "results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.SearchResult"
+                    }
+                }
+            }
+        },
+        "dto.SearchResult": {
+            "type": "object",
+            "properties": {
+                "distance": {
+                    "type": "number"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "lat": {
+                    "type": "number"
+                },
+                "long": {
+                    "type": "number"
+                },
+                "route": {
+                    "type": "string"
+                },
+                "score": {
+                    "type": "number"
+                },
+                "subtitle": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ServiceException": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "endDate": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "route": {
+                    "type": "string"
+                },
+                "startDate": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.StationArrivalResponse": {
+            "type": "object",
+            "properties": {
+                "bus": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.BusInfo"
+                    }
+                },
+                "closures": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ClosureInfo"
+                    }
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
                     "type": "string"
+                },
+                "service": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.RouteServiceStatus"
+                    }
                 }
             }
         },
-        "dto.CreateBusResponse": {
+        "dto.StationResponse": {
             "type": "object",
             "properties": {
+                "distance": {
+                    "type": "number"
+                },
                 "id": {
                     "type": "integer"
                 },
-                "isActive": {
-                    "type": "boolean"
-                },
-                "number": {
-                    "type": "integer"
+                "lat": {
+                    "type": "number"
                 },
-                "plate": {
-                    "type": "string"
+                "long": {
+                    "type": "number"
                 },
-                "route": {
+                "name": {
                     "type": "string"
                 },
-                "status": {
-                    "type": "string"
+                "places": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "username": {
-                    "type": "string"
+                "stops": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.StationStop"
+                    }
                 }
             }
         },
-        "dto.CreateNewsDto": {
+        "dto.StationStop": {
             "type": "object",
-            "required": [
-                "detail",
-                "title"
-            ],
             "properties": {
-                "detail": {
-                    "type": "string"
+                "bus": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.BusInfo"
+                    }
                 },
-                "title": {
-                    "type": "string"
-                }
-            }
-        },
-        "dto.CreateNewsResponse": {
-            "type": "object",
-            "properties": {
-                "createdAt": {
+                "closure": {
+                    "$ref": "#/definitions/dto.ClosureInfo"
+                },
+                "lat": {
+                    "type": "number"
+                },
+                "long": {
+                    "type": "number"
+                },
+                "next": {
                     "type": "string"
                 },
-                "detail": {
+                "route": {
                     "type": "string"
                 },
-                "id": {
-                    "type": "integer"
+                "service": {
+                    "$ref": "#/definitions/dto.RouteServiceStatus"
                 },
-                "title": {
-                    "type": "string"
+                "terminalId": {
+                    "type": "integer"
                 }
             }
         },
-        "dto.DriverLoginDto": {
+        "dto.Status": {
             "type": "object",
-            "required": [
-                "password",
-                "username"
-            ],
             "properties": {
-                "password": {
+                "data": {},
+                "name": {
                     "type": "string"
                 },
-                "username": {
+                "status": {
                     "type": "string"
                 }
             }
         },
-        "dto.DriverLoginResponse": {
+        "dto.TerminalEvent": {
             "type": "object",
             "properties": {
-                "id": {
+                "busId": {
                     "type": "integer"
                 },
-                "isActive": {
-                    "type": "boolean"
+                "dwell": {
+                    "type": "number"
                 },
-                "number": {
+                "id": {
                     "type": "integer"
                 },
-                "plate": {
-                    "type": "string"
-                },
                 "route": {
                     "type": "string"
                 },
-                "status": {
+                "terminalId": {
+                    "type": "integer"
+                },
+                "timestamp": {
                     "type": "string"
                 },
-                "token": {
+                "type": {
                     "type": "string"
                 }
             }
         },
-        "dto.EditBusDto": {
+        "dto.TerminalListWithDistance": {
             "type": "object",
             "properties": {
-                "isActive": {
-                    "type": "boolean"
+                "closure": {
+                    "$ref": "#/definitions/dto.ClosureInfo"
                 },
-                "number": {
+                "distance": {
+                    "type": "number"
+                },
+                "id": {
                     "type": "integer"
                 },
-                "plate": {
+                "name": {
+                    "type": "string"
+                },
+                "next": {
                     "type": "string"
                 },
                 "route": {
-                    "type": "string",
-                    "enum": [
-                        "RED",
-                        "BLUE"
-                    ]
+                    "type": "string"
                 },
-                "status": {
-                    "type": "string",
-                    "enum": [
-                        "EMPTY",
-                        "MODERATE",
-                        "FULL"
-                    ]
+                "walkMinute": {
+                    "type": "integer"
                 }
             }
         },
-        "dto.EditBusResponse": {
+        "dto.TerminalResponse": {
             "type": "object",
             "properties": {
                 "id": {
                     "type": "integer"
                 },
-                "isActive": {
-                    "type": "boolean"
+                "lat": {
+                    "type": "number"
                 },
-                "number": {
-                    "type": "integer"
+                "long": {
+                    "type": "number"
                 },
-                "plate": {
+                "name": {
                     "type": "string"
                 },
-                "route": {
+                "nameEn": {
                     "type": "string"
                 },
-                "status": {
+                "places": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "route": {
                     "type": "string"
+                },
+                "sequence": {
+                    "type": "integer"
                 }
             }
         },
-        "dto.EditNewsDto": {
+        "dto.TripBus": {
             "type": "object",
             "properties": {
-                "detail": {
-                    "type": "string"
+                "id": {
+                    "type": "integer"
                 },
-                "title": {
+                "number": {
+                    "type": "integer"
+                },
+                "plate": {
                     "type": "string"
                 }
             }
         },
-        "dto.GetAllNewsResponse": {
+        "dto.TripOption": {
             "type": "object",
             "properties": {
-                "news": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/dto.News"
-                    }
+                "alight": {
+                    "$ref": "#/definitions/dto.TripTerminal"
+                },
+                "board": {
+                    "$ref": "#/definitions/dto.TripTerminal"
+                },
+                "bus": {
+                    "$ref": "#/definitions/dto.TripBus"
+                },
+                "rideMinute": {
+                    "type": "integer"
+                },
+                "route": {
+                    "type": "string"
+                },
+                "stops": {
+                    "type": "integer"
+                },
+                "totalMinute": {
+                    "type": "integer"
+                },
+                "waitMinute": {
+                    "type": "integer"
+                },
+                "walkDistance": {
+                    "type": "number"
+                },
+                "walkMinute": {
+                    "type": "integer"
                 }
             }
         },
-        "dto.GetAllTerminalDto": {
+        "dto.TripTerminal": {
             "type": "object",
-            "required": [
-                "lat",
-                "long"
-            ],
             "properties": {
+                "id": {
+                    "type": "integer"
+                },
                 "lat": {
                     "type": "number"
                 },
                 "long": {
                     "type": "number"
-                }
-            }
-        },
-        "dto.GetAllTerminalResponse": {
-            "type": "object",
-            "properties": {
-                "terminal": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/dto.TerminalListWithDistance"
-                    }
-                }
-            }
-        },
-        "dto.GetTerminalInfoResponse": {
-            "type": "object",
-            "properties": {
-                "name": {
-                    "type": "string"
-                },
-                "relatedPlace": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
-                },
-                "relatedTerminal": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/dto.VisitedTerminal"
-                    }
                 },
-                "route": {
+                "name": {
                     "type": "string"
                 }
             }
         },
-        "dto.News": {
+        "dto.UpcomingStop": {
             "type": "object",
             "properties": {
-                "createdAt": {
+                "arrivalAt": {
                     "type": "string"
                 },
-                "detail": {
-                    "type": "string"
+                "distance": {
+                    "type": "number"
+                },
+                "estimate": {
+                    "type": "integer"
                 },
                 "id": {
                     "type": "integer"
                 },
-                "title": {
-                    "type": "string"
-                }
-            }
-        },
-        "dto.Status": {
-            "type": "object",
-            "properties": {
-                "data": {},
                 "name": {
                     "type": "string"
                 },
-                "status": {
-                    "type": "string"
+                "stopsAway": {
+                    "type": "integer"
                 }
             }
         },
-        "dto.TerminalListWithDistance": {
+        "dto.UpcomingStopResponse": {
             "type": "object",
             "properties": {
-                "distance": {
+                "id": {
+                    "type": "integer"
+                },
+                "lat": {
                     "type": "number"
                 },
-                "id": {
+                "long": {
+                    "type": "number"
+                },
+                "number": {
                     "type": "integer"
                 },
-                "name": {
+                "plate": {
                     "type": "string"
                 },
-                "next": {
+                "route": {
                     "type": "string"
                 },
-                "route": {
+                "status": {
                     "type": "string"
+                },
+                "stops": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.UpcomingStop"
+                    }
                 }
             }
         },
         "dto.VisitedTerminal": {
             "type": "object",
             "properties": {
+                "closed": {
+                    "type": "boolean"
+                },
                 "id": {
                     "type": "integer"
                 },
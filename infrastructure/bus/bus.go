@@ -30,6 +30,7 @@ func (c *Controller) Routes(app *fiber.App) {
 	bus.Delete("/:id", c.delete)
 	bus.Put("/:id", c.edit)
 	bus.Post("/info/:id", c.busInfo)
+	bus.Get("/upcoming/:id", c.upcomingStop)
 
 	bus.Use("/stream", func(ctx *fiber.Ctx) error {
 		if websocket.IsWebSocketUpgrade(ctx) {
@@ -38,6 +39,7 @@ func (c *Controller) Routes(app *fiber.App) {
 		return fiber.ErrUpgradeRequired
 	})
 	bus.Get("/stream", websocket.New(c.trackBusLocation))
+	bus.Get("/stream/upcoming/:id", websocket.New(c.streamUpcomingStop))
 	bus.Get("/streamfirebase", websocket.New(c.trackBusLocationFirebase))
 }
 
@@ -195,8 +197,9 @@ func (c *Controller) edit(ctx *fiber.Ctx) error {
 // All godoc
 // @Tags Bus
 // @Summary Get bus estimation
-// @Description Put all mandatory parameter
+// @Description Closed terminal return the closure reason without bus estimation
 // @Param id path string true "Terminal ID"
+// @Param lang query string false "response language" Enums(id, en)
 // @Accept  json
 // @Produce  json
 // @Success 200 {object} dto.BusInfoResponse
@@ -211,7 +214,34 @@ func (c *Controller) busInfo(ctx *fiber.Ctx) error {
 
 	c.Shared.Logger.Infof("bus info, data: %s", id)
 
-	response, err := c.Interfaces.BusViewService.BusInfo(id)
+	response, err := c.Interfaces.BusViewService.BusInfo(id, common.GetLocale(ctx))
+	if err != nil {
+		return common.DoCommonErrorResponse(ctx, err)
+	}
+
+	return common.DoCommonSuccessResponse(ctx, response)
+}
+
+// All godoc
+// @Tags Bus
+// @Summary Get upcoming terminal of a bus
+// @Description Every upcoming terminal in route order with arrival estimation
+// @Param id path string true "Bus ID"
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} dto.UpcomingStopResponse
+// @Failure 200 {object} dto.UpcomingStopResponse
+// @Router /bus/upcoming/{id} [get]
+func (c *Controller) upcomingStop(ctx *fiber.Ctx) error {
+	var (
+		response dto.UpcomingStopResponse
+	)
+
+	id := ctx.Params("id")
+
+	c.Shared.Logger.Infof("upcoming stop, data: %s", id)
+
+	response, err := c.Interfaces.BusViewService.UpcomingStop(id)
 	if err != nil {
 		return common.DoCommonErrorResponse(ctx, err)
 	}
@@ -219,12 +249,43 @@ func (c *Controller) busInfo(ctx *fiber.Ctx) error {
 	return common.DoCommonSuccessResponse(ctx, response)
 }
 
+/**
+ * Stream upcoming terminal of a bus every second using websocket
+ * @param id bus identifier
+ */
+func (c *Controller) streamUpcomingStop(ctx *websocket.Conn) {
+	defer func() {
+		ctx.Close()
+	}()
+
+	id := ctx.Params("id")
+
+	c.Shared.Logger.Infof("stream upcoming stop, data: %s", id)
+
+	for {
+		response, err := c.Interfaces.BusViewService.UpcomingStop(id)
+		if err != nil {
+			err = ctx.WriteJSON(common.Response{Status: "FAILED", Error: err.Error()})
+		} else {
+			err = ctx.WriteJSON(response)
+		}
+
+		if err != nil {
+			return
+		}
+		time.Sleep(1 * time.Second)
+	}
+}
+
 /**
  * Track bus location using websocket
  * @param type to differentiate between driver and client
  * @param token authentication token used only if type is driver
  * @param experimental toggler for experimnetal tracking using bot
  * @param expeerimentalId bus identifier for bot
+ * @param sandbox sandbox name used only if experimental
+ * @param sandboxToken sandbox token used only if experimental
+ * @param schedule include route service status in rider message
  */
 func (c *Controller) trackBusLocation(ctx *websocket.Conn) {
 	defer func() {
@@ -234,12 +295,19 @@ func (c *Controller) trackBusLocation(ctx *websocket.Conn) {
 	query := dto.BusLocationQuery{
 		Type:           ctx.Query("type", string(dto.CLIENT)),
 		Token:          ctx.Query("token", ""),
-		Experimental:   ctx.Query("experimental", c.Shared.Env.Experimental),
+		Experimental:   ctx.Query("experimental", "false"),
 		ExperminetalID: ctx.Query("experimentalId", ""),
+		Sandbox:        ctx.Query("sandbox", ""),
+		SandboxToken:   ctx.Query("sandboxToken", ""),
+		Schedule:       ctx.Query("schedule", "false"),
 	}
 
 	c.Shared.Logger.Infof("stream bus location, query: %s", query)
 
+	if !c.authorizeSandbox(ctx, query) {
+		return
+	}
+
 	for {
 		if query.Type == string(dto.DRIVER) {
 			data, err := c.Interfaces.BusViewService.TrackBusLocation(query, ctx)
@@ -249,7 +317,7 @@ func (c *Controller) trackBusLocation(ctx *websocket.Conn) {
 			ctx.WriteJSON(data)
 		} else {
 			busLocation := c.Interfaces.BusViewService.StreamBusLocation(query)
-			ctx.WriteJSON(busLocation)
+			ctx.WriteJSON(c.Interfaces.BusViewService.StreamMessage(query, busLocation))
 			time.Sleep(1 * time.Second)
 		}
 	}
@@ -261,6 +329,9 @@ func (c *Controller) trackBusLocation(ctx *websocket.Conn) {
  * @param token authentication token used only if type is driver
  * @param experimental toggler for experimnetal tracking using bot
  * @param experimentalId bus identifier for bot
+ * @param sandbox sandbox name used only if experimental
+ * @param sandboxToken sandbox token used only if experimental
+ * @param schedule include route service status in rider message
  */
  func (c *Controller) trackBusLocationFirebase(ctx *websocket.Conn) {
 	firebaseCtx := context.Background()
@@ -283,12 +354,19 @@ func (c *Controller) trackBusLocation(ctx *websocket.Conn) {
 	query := dto.BusLocationQuery{
 		Type:           ctx.Query("type", string(dto.CLIENT)),
 		Token:          ctx.Query("token", ""),
-		Experimental:   ctx.Query("experimental", c.Shared.Env.Experimental),
+		Experimental:   ctx.Query("experimental", "false"),
 		ExperminetalID: ctx.Query("experimentalId", ""),
+		Sandbox:        ctx.Query("sandbox", ""),
+		SandboxToken:   ctx.Query("sandboxToken", ""),
+		Schedule:       ctx.Query("schedule", "false"),
 	}
 
 	c.Shared.Logger.Infof("stream bus location firebase, query: %s", query)
 
+	if !c.authorizeSandbox(ctx, query) {
+		return
+	}
+
 	for {
 		if query.Type == string(dto.DRIVER) {
 			data, err := c.Interfaces.BusViewService.TrackBusLocationFirebase(query, ctx, client, firebaseCtx)
@@ -296,10 +374,38 @@ func (c *Controller) trackBusLocation(ctx *websocket.Conn) {
 				return
 			}
 			ctx.WriteJSON(data)
+		} else if query.Experimental == "true" {
+			busLocation := c.Interfaces.BusViewService.StreamBusLocation(query)
+			ctx.WriteJSON(c.Interfaces.BusViewService.StreamMessage(query, busLocation))
+			time.Sleep(1 * time.Second)
+		} else {
+			c.Interfaces.BusViewService.StreamBusLocationFirebase(query, ctx, client, firebaseCtx)
+			return
 		}
 	}
 }
 
+/**
+ * Check sandbox access once when experimental connection opened
+ * Only connection asking for experimental itself checked, rider without sandbox param always served
+ */
+func (c *Controller) authorizeSandbox(ctx *websocket.Conn, query dto.BusLocationQuery) bool {
+	if query.Experimental != "true" {
+		return true
+	}
+
+	_, err := c.Interfaces.SandboxViewService.Authorize(query.Sandbox, query.SandboxToken)
+	if err != nil {
+		ctx.WriteJSON(common.Response{
+			Status: "FAILED",
+			Error:  err.Error(),
+		})
+		return false
+	}
+
+	return true
+}
+
 func NewController(interfaces interfaces.Holder, shared shared.Holder) Controller {
 	return Controller{
 		Interfaces: interfaces,
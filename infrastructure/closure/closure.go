@@ -0,0 +1,167 @@
+package closure
+
+import (
+	"tracking-server/interfaces"
+	"tracking-server/shared"
+	"tracking-server/shared/common"
+	"tracking-server/shared/dto"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type Controller struct {
+	Interfaces interfaces.Holder
+	Shared     shared.Holder
+}
+
+func (c *Controller) Routes(app *fiber.App) {
+	closure := app.Group("/closure")
+	closure.Get("/", c.getAll)
+	closure.Get("/:id", c.get)
+	closure.Post("/", c.create)
+	closure.Put("/:id", c.edit)
+	closure.Delete("/:id", c.delete)
+}
+
+// All godoc
+// @Tags Closure
+// @Summary Get all terminal closure and detour
+// @Description Put all mandatory parameter
+// @Param route query string false "route code"
+// @Param active query bool false "only closure in effect or upcoming"
+// @Produce  json
+// @Success 200 {object} dto.GetAllClosureResponse
+// @Failure 200 {object} dto.GetAllClosureResponse
+// @Router /closure/ [get]
+func (c *Controller) getAll(ctx *fiber.Ctx) error {
+	var (
+		query dto.GetAllClosureQuery
+	)
+
+	err := common.DoCommonQuery(ctx, &query)
+	if err != nil {
+		return common.DoCommonErrorResponse(ctx, err)
+	}
+
+	c.Shared.Logger.Infof("get all closure, data: %v", query)
+
+	res, err := c.Interfaces.ClosureViewService.GetAllClosure(query)
+	if err != nil {
+		return common.DoCommonErrorResponse(ctx, err)
+	}
+
+	return common.DoCommonSuccessResponse(ctx, res)
+}
+
+// All godoc
+// @Tags Closure
+// @Summary Get closure detail
+// @Description Put all mandatory parameter
+// @Param id path string true "closure id"
+// @Produce  json
+// @Success 200 {object} dto.ClosureResponse
+// @Failure 200 {object} dto.ClosureResponse
+// @Router /closure/{id} [get]
+func (c *Controller) get(ctx *fiber.Ctx) error {
+	id := ctx.Params("id")
+
+	c.Shared.Logger.Infof("get closure, data: %s", id)
+
+	res, err := c.Interfaces.ClosureViewService.GetClosure(id)
+	if err != nil {
+		return common.DoCommonErrorResponse(ctx, err)
+	}
+
+	return common.DoCommonSuccessResponse(ctx, res)
+}
+
+// All godoc
+// @Tags Closure
+// @Summary Create terminal closure or detour
+// @Description Closed terminal not served by the route between start and end time, detour carry the alternative path
+// @Param CreateClosureDto body dto.CreateClosureDto true "CreateClosure"
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} dto.ClosureResponse
+// @Failure 200 {object} dto.ClosureResponse
+// @Router /closure/ [post]
+func (c *Controller) create(ctx *fiber.Ctx) error {
+	var (
+		body dto.CreateClosureDto
+	)
+
+	err := common.DoCommonRequest(ctx, &body)
+	if err != nil {
+		return common.DoCommonErrorResponse(ctx, err)
+	}
+
+	c.Shared.Logger.Infof("create closure, data: %v", body)
+
+	res, err := c.Interfaces.ClosureViewService.CreateClosure(body)
+	if err != nil {
+		return common.DoCommonErrorResponse(ctx, err)
+	}
+
+	return common.DoCommonSuccessResponse(ctx, res)
+}
+
+// All godoc
+// @Tags Closure
+// @Summary Edit closure
+// @Description Put all mandatory parameter
+// @Param id path string true "closure id"
+// @Param EditClosureDto body dto.EditClosureDto true "EditClosure"
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} dto.ClosureResponse
+// @Failure 200 {object} dto.ClosureResponse
+// @Router /closure/{id} [put]
+func (c *Controller) edit(ctx *fiber.Ctx) error {
+	var (
+		body dto.EditClosureDto
+	)
+
+	err := common.DoCommonRequest(ctx, &body)
+	if err != nil {
+		return common.DoCommonErrorResponse(ctx, err)
+	}
+
+	id := ctx.Params("id")
+
+	c.Shared.Logger.Infof("edit closure, data: %v, id: %s", body, id)
+
+	res, err := c.Interfaces.ClosureViewService.EditClosure(body, id)
+	if err != nil {
+		return common.DoCommonErrorResponse(ctx, err)
+	}
+
+	return common.DoCommonSuccessResponse(ctx, res)
+}
+
+// All godoc
+// @Tags Closure
+// @Summary Delete closure
+// @Description Put all mandatory parameter
+// @Param id path string true "closure id"
+// @Accept  json
+// @Produce  json
+// @Router /closure/{id} [delete]
+func (c *Controller) delete(ctx *fiber.Ctx) error {
+	id := ctx.Params("id")
+
+	c.Shared.Logger.Infof("delete closure, data: %s", id)
+
+	err := c.Interfaces.ClosureViewService.DeleteClosure(id)
+	if err != nil {
+		return common.DoCommonErrorResponse(ctx, err)
+	}
+
+	return common.DoCommonSuccessResponse(ctx, nil)
+}
+
+func NewController(interfaces interfaces.Holder, shared shared.Holder) Controller {
+	return Controller{
+		Interfaces: interfaces,
+		Shared:     shared,
+	}
+}
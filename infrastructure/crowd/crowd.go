@@ -0,0 +1,92 @@
+package crowd
+
+import (
+	"tracking-server/interfaces"
+	"tracking-server/shared"
+	"tracking-server/shared/common"
+	"tracking-server/shared/dto"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type Controller struct {
+	Interfaces interfaces.Holder
+	Shared     shared.Holder
+}
+
+func (c *Controller) Routes(app *fiber.App) {
+	crowd := app.Group("/crowd")
+	crowd.Get("/statistic", c.statistic)
+	crowd.Get("/predict/:id", c.predict)
+}
+
+// All godoc
+// @Tags Crowd
+// @Summary Get crowding statistic per route and hour
+// @Description Share of time spent on each crowd status for every hour of the day in WIB
+// @Param route query string false "route code"
+// @Param days query int false "history in day, default 28"
+// @Produce  json
+// @Success 200 {object} dto.GetCrowdStatisticResponse
+// @Failure 200 {object} dto.GetCrowdStatisticResponse
+// @Router /crowd/statistic [get]
+func (c *Controller) statistic(ctx *fiber.Ctx) error {
+	var (
+		query dto.CrowdStatisticQuery
+	)
+
+	err := common.DoCommonQuery(ctx, &query)
+	if err != nil {
+		return common.DoCommonErrorResponse(ctx, err)
+	}
+
+	c.Shared.Logger.Infof("get crowd statistic, data: %v", query)
+
+	res, err := c.Interfaces.CrowdViewService.GetStatistic(query)
+	if err != nil {
+		return common.DoCommonErrorResponse(ctx, err)
+	}
+
+	return common.DoCommonSuccessResponse(ctx, res)
+}
+
+// All godoc
+// @Tags Crowd
+// @Summary Predict crowd level at a terminal
+// @Description Time window start at the given time in RFC3339, default now
+// @Param id path string true "terminal ID"
+// @Param at query string false "window start"
+// @Param window query int false "window in minute, default 60"
+// @Param days query int false "history in day, default 28"
+// @Produce  json
+// @Success 200 {object} dto.PredictCrowdResponse
+// @Failure 200 {object} dto.PredictCrowdResponse
+// @Router /crowd/predict/{id} [get]
+func (c *Controller) predict(ctx *fiber.Ctx) error {
+	var (
+		query dto.PredictCrowdQuery
+	)
+
+	err := common.DoCommonQuery(ctx, &query)
+	if err != nil {
+		return common.DoCommonErrorResponse(ctx, err)
+	}
+
+	id := ctx.Params("id")
+
+	c.Shared.Logger.Infof("predict crowd, data: %v, id: %s", query, id)
+
+	res, err := c.Interfaces.CrowdViewService.PredictCrowd(id, query)
+	if err != nil {
+		return common.DoCommonErrorResponse(ctx, err)
+	}
+
+	return common.DoCommonSuccessResponse(ctx, res)
+}
+
+func NewController(interfaces interfaces.Holder, shared shared.Holder) Controller {
+	return Controller{
+		Interfaces: interfaces,
+		Shared:     shared,
+	}
+}
@@ -2,9 +2,22 @@ package infrastructure
 
 import (
 	"tracking-server/infrastructure/bus"
+	"tracking-server/infrastructure/closure"
+	"tracking-server/infrastructure/crowd"
+	"tracking-server/infrastructure/geofence"
+	"tracking-server/infrastructure/headway"
 	"tracking-server/infrastructure/healthcheck"
 	"tracking-server/infrastructure/news"
+	"tracking-server/infrastructure/place"
+	"tracking-server/infrastructure/report"
+	"tracking-server/infrastructure/route"
+	"tracking-server/infrastructure/sandbox"
+	"tracking-server/infrastructure/schedule"
+	"tracking-server/infrastructure/search"
+	"tracking-server/infrastructure/station"
+	"tracking-server/infrastructure/subscription"
 	"tracking-server/infrastructure/terminal"
+	"tracking-server/infrastructure/trip"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/pkg/errors"
@@ -13,10 +26,23 @@ import (
 
 type Holder struct {
 	dig.In
-	Healthcheck healthcheck.Controller
-	Bus         bus.Controller
-	News        news.Controller
-	Terminal    terminal.Controller
+	Healthcheck  healthcheck.Controller
+	Bus          bus.Controller
+	News         news.Controller
+	Terminal     terminal.Controller
+	Station      station.Controller
+	Route        route.Controller
+	Closure      closure.Controller
+	Place        place.Controller
+	Search       search.Controller
+	Sandbox      sandbox.Controller
+	Geofence     geofence.Controller
+	Headway      headway.Controller
+	Subscription subscription.Controller
+	Trip         trip.Controller
+	Schedule     schedule.Controller
+	Crowd        crowd.Controller
+	Report       report.Controller
 }
 
 /**
@@ -35,10 +61,62 @@ func Register(container *dig.Container) error {
 		return errors.Wrap(err, "failed to provide news controller")
 	}
 
+	if err := container.Provide(route.NewController); err != nil {
+		return errors.Wrap(err, "failed to provide route controller")
+	}
+
+	if err := container.Provide(closure.NewController); err != nil {
+		return errors.Wrap(err, "failed to provide closure controller")
+	}
+
+	if err := container.Provide(place.NewController); err != nil {
+		return errors.Wrap(err, "failed to provide place controller")
+	}
+
+	if err := container.Provide(search.NewController); err != nil {
+		return errors.Wrap(err, "failed to provide search controller")
+	}
+
 	if err := container.Provide(terminal.NewController); err != nil {
 		return errors.Wrap(err, "failed to provide terminal controller")
 	}
 
+	if err := container.Provide(station.NewController); err != nil {
+		return errors.Wrap(err, "failed to provide station controller")
+	}
+
+	if err := container.Provide(sandbox.NewController); err != nil {
+		return errors.Wrap(err, "failed to provide sandbox controller")
+	}
+
+	if err := container.Provide(geofence.NewController); err != nil {
+		return errors.Wrap(err, "failed to provide geofence controller")
+	}
+
+	if err := container.Provide(headway.NewController); err != nil {
+		return errors.Wrap(err, "failed to provide headway controller")
+	}
+
+	if err := container.Provide(subscription.NewController); err != nil {
+		return errors.Wrap(err, "failed to provide subscription controller")
+	}
+
+	if err := container.Provide(trip.NewController); err != nil {
+		return errors.Wrap(err, "failed to provide trip controller")
+	}
+
+	if err := container.Provide(schedule.NewController); err != nil {
+		return errors.Wrap(err, "failed to provide schedule controller")
+	}
+
+	if err := container.Provide(crowd.NewController); err != nil {
+		return errors.Wrap(err, "failed to provide crowd controller")
+	}
+
+	if err := container.Provide(report.NewController); err != nil {
+		return errors.Wrap(err, "failed to provide report controller")
+	}
+
 	return nil
 }
 
@@ -50,4 +128,17 @@ func Routes(app *fiber.App, controller Holder) {
 	controller.Bus.Routes(app)
 	controller.News.Routes(app)
 	controller.Terminal.Routes(app)
+	controller.Station.Routes(app)
+	controller.Route.Routes(app)
+	controller.Closure.Routes(app)
+	controller.Place.Routes(app)
+	controller.Search.Routes(app)
+	controller.Sandbox.Routes(app)
+	controller.Geofence.Routes(app)
+	controller.Headway.Routes(app)
+	controller.Subscription.Routes(app)
+	controller.Trip.Routes(app)
+	controller.Schedule.Routes(app)
+	controller.Crowd.Routes(app)
+	controller.Report.Routes(app)
 }
@@ -0,0 +1,62 @@
+package geofence
+
+import (
+	"tracking-server/interfaces"
+	"tracking-server/shared"
+	"tracking-server/shared/common"
+	"tracking-server/shared/dto"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type Controller struct {
+	Interfaces interfaces.Holder
+	Shared     shared.Holder
+}
+
+func (c *Controller) Routes(app *fiber.App) {
+	geofence := app.Group("/geofence")
+	geofence.Get("/event", c.event)
+}
+
+// All godoc
+// @Tags Geofence
+// @Summary Get terminal arrival and departure event
+// @Description Filter is optional, from and to in RFC3339 format
+// @Param busId query int false "bus ID"
+// @Param terminalId query int false "terminal ID"
+// @Param route query string false "route code"
+// @Param type query string false "event type" Enums(ARRIVAL, DEPARTURE)
+// @Param from query string false "start time"
+// @Param to query string false "end time"
+// @Param limit query int false "max event, default 100"
+// @Produce  json
+// @Success 200 {object} dto.GetTerminalEventResponse
+// @Failure 200 {object} dto.GetTerminalEventResponse
+// @Router /geofence/event [get]
+func (c *Controller) event(ctx *fiber.Ctx) error {
+	var (
+		query dto.TerminalEventQuery
+	)
+
+	err := common.DoCommonQuery(ctx, &query)
+	if err != nil {
+		return common.DoCommonErrorResponse(ctx, err)
+	}
+
+	c.Shared.Logger.Infof("get terminal event, data: %v", query)
+
+	res, err := c.Interfaces.GeofenceViewService.GetTerminalEvent(query)
+	if err != nil {
+		return common.DoCommonErrorResponse(ctx, err)
+	}
+
+	return common.DoCommonSuccessResponse(ctx, res)
+}
+
+func NewController(interfaces interfaces.Holder, shared shared.Holder) Controller {
+	return Controller{
+		Interfaces: interfaces,
+		Shared:     shared,
+	}
+}
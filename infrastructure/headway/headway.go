@@ -0,0 +1,78 @@
+package headway
+
+import (
+	"tracking-server/interfaces"
+	"tracking-server/shared"
+	"tracking-server/shared/common"
+	"tracking-server/shared/dto"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/websocket/v2"
+)
+
+type Controller struct {
+	Interfaces interfaces.Holder
+	Shared     shared.Holder
+}
+
+func (c *Controller) Routes(app *fiber.App) {
+	headway := app.Group("/headway")
+	headway.Get("/", c.get)
+
+	headway.Use("/stream", func(ctx *fiber.Ctx) error {
+		if websocket.IsWebSocketUpgrade(ctx) {
+			return ctx.Next()
+		}
+		return fiber.ErrUpgradeRequired
+	})
+	headway.Get("/stream", websocket.New(c.streamAlert))
+}
+
+// All godoc
+// @Tags Headway
+// @Summary Get headway between consecutive bus of each route
+// @Description Headway in minute to the bus ahead, status is NORMAL, BUNCHING or GAP
+// @Param route query string false "route code"
+// @Produce  json
+// @Success 200 {object} dto.GetHeadwayResponse
+// @Failure 200 {object} dto.GetHeadwayResponse
+// @Router /headway/ [get]
+func (c *Controller) get(ctx *fiber.Ctx) error {
+	var (
+		query dto.GetHeadwayQuery
+	)
+
+	err := common.DoCommonQuery(ctx, &query)
+	if err != nil {
+		return common.DoCommonErrorResponse(ctx, err)
+	}
+
+	c.Shared.Logger.Infof("get headway, data: %v", query)
+
+	res := c.Interfaces.HeadwayViewService.GetHeadway(query)
+
+	return common.DoCommonSuccessResponse(ctx, res)
+}
+
+/**
+ * Stream bunching and gap alert to dispatcher using websocket
+ */
+func (c *Controller) streamAlert(ctx *websocket.Conn) {
+	defer func() {
+		ctx.Close()
+	}()
+
+	c.Shared.Logger.Infof("stream headway alert")
+
+	err := c.Interfaces.HeadwayViewService.StreamAlert(ctx)
+	if err != nil {
+		c.Shared.Logger.Errorf("error when streaming headway alert, err: %s", err.Error())
+	}
+}
+
+func NewController(interfaces interfaces.Holder, shared shared.Holder) Controller {
+	return Controller{
+		Interfaces: interfaces,
+		Shared:     shared,
+	}
+}
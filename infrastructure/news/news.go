@@ -58,6 +58,7 @@ func (c *Controller) create(ctx *fiber.Ctx) error {
 // @Tags News
 // @Summary Get all news
 // @Description Put all mandatory parameter
+// @Param lang query string false "response language" Enums(id, en)
 // @Accept  json
 // @Produce  json
 // @Success 200 {object} dto.GetAllNewsResponse
@@ -68,7 +69,7 @@ func (c *Controller) getAll(ctx *fiber.Ctx) error {
 		response dto.GetAllNewsResponse
 	)
 
-	response, err := c.Interfaces.NewsViewService.GetAllNews()
+	response, err := c.Interfaces.NewsViewService.GetAllNews(common.GetLocale(ctx))
 	if err != nil {
 		return common.DoCommonErrorResponse(ctx, err)
 	}
@@ -81,6 +82,7 @@ func (c *Controller) getAll(ctx *fiber.Ctx) error {
 // @Summary Get news detail
 // @Description Put all mandatory parameter
 // @Param id path string true "News ID"
+// @Param lang query string false "response language" Enums(id, en)
 // @Accept  json
 // @Produce  json
 // @Success 200 {object} dto.News
@@ -91,7 +93,7 @@ func (c *Controller) get(ctx *fiber.Ctx) error {
 
 	c.Shared.Logger.Infof("get news detail, data: %s", id)
 
-	res, err := c.Interfaces.NewsViewService.GetNewsDetail(id)
+	res, err := c.Interfaces.NewsViewService.GetNewsDetail(id, common.GetLocale(ctx))
 	if err != nil {
 		return common.DoCommonErrorResponse(ctx, err)
 	}
@@ -0,0 +1,84 @@
+package place
+
+import (
+	"tracking-server/interfaces"
+	"tracking-server/shared"
+	"tracking-server/shared/common"
+	"tracking-server/shared/dto"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type Controller struct {
+	Interfaces interfaces.Holder
+	Shared     shared.Holder
+}
+
+func (c *Controller) Routes(app *fiber.App) {
+	place := app.Group("/place")
+	place.Get("/search", c.search)
+	place.Get("/:id", c.get)
+}
+
+// All godoc
+// @Tags Place
+// @Summary Search place by name or alias
+// @Description Rider location is optional, used to pick the boarding terminal of each route
+// @Param q query string true "place name or alias"
+// @Param lat query number false "rider latitude"
+// @Param long query number false "rider longitude"
+// @Param limit query int false "max place returned"
+// @Param lang query string false "response language" Enums(id, en)
+// @Produce  json
+// @Success 200 {object} dto.SearchPlaceResponse
+// @Failure 200 {object} dto.SearchPlaceResponse
+// @Router /place/search [get]
+func (c *Controller) search(ctx *fiber.Ctx) error {
+	var (
+		query dto.SearchPlaceQuery
+	)
+
+	err := common.DoCommonQuery(ctx, &query)
+	if err != nil {
+		return common.DoCommonErrorResponse(ctx, err)
+	}
+
+	c.Shared.Logger.Infof("search place, data: %v", query)
+
+	res, err := c.Interfaces.PlaceViewService.SearchPlace(query, common.GetLocale(ctx))
+	if err != nil {
+		return common.DoCommonErrorResponse(ctx, err)
+	}
+
+	return common.DoCommonSuccessResponse(ctx, res)
+}
+
+// All godoc
+// @Tags Place
+// @Summary Get place detail
+// @Description Put all mandatory parameter
+// @Param id path string true "place ID"
+// @Param lang query string false "response language" Enums(id, en)
+// @Produce  json
+// @Success 200 {object} dto.PlaceResponse
+// @Failure 200 {object} dto.PlaceResponse
+// @Router /place/{id} [get]
+func (c *Controller) get(ctx *fiber.Ctx) error {
+	id := ctx.Params("id")
+
+	c.Shared.Logger.Infof("get place, data: %s", id)
+
+	res, err := c.Interfaces.PlaceViewService.GetPlace(id, common.GetLocale(ctx))
+	if err != nil {
+		return common.DoCommonErrorResponse(ctx, err)
+	}
+
+	return common.DoCommonSuccessResponse(ctx, res)
+}
+
+func NewController(interfaces interfaces.Holder, shared shared.Holder) Controller {
+	return Controller{
+		Interfaces: interfaces,
+		Shared:     shared,
+	}
+}
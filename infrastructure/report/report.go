@@ -0,0 +1,124 @@
+package report
+
+import (
+	"tracking-server/interfaces"
+	"tracking-server/shared"
+	"tracking-server/shared/common"
+	"tracking-server/shared/dto"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type Controller struct {
+	Interfaces interfaces.Holder
+	Shared     shared.Holder
+}
+
+func (c *Controller) Routes(app *fiber.App) {
+	report := app.Group("/report")
+	report.Post("/", c.create)
+	report.Get("/review", c.reviewQueue)
+	report.Put("/review/:id", c.review)
+}
+
+// All godoc
+// @Tags Report
+// @Summary Submit rider report of a bus
+// @Description Status required for CROWD, terminalId for SKIPPED_STOP and message for OTHER
+// @Param CreateReportDto body dto.CreateReportDto true "CreateReport"
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} dto.CreateReportResponse
+// @Failure 200 {object} dto.CreateReportResponse
+// @Router /report/ [post]
+func (c *Controller) create(ctx *fiber.Ctx) error {
+	var (
+		body     dto.CreateReportDto
+		response dto.CreateReportResponse
+	)
+
+	err := common.DoCommonRequest(ctx, &body)
+	if err != nil {
+		return common.DoCommonErrorResponse(ctx, err)
+	}
+
+	c.Shared.Logger.Infof("create rider report, data: %v", body)
+
+	response, err = c.Interfaces.ReportViewService.CreateReport(body, ctx.IP())
+	if err != nil {
+		return common.DoCommonErrorResponse(ctx, err)
+	}
+
+	return common.DoCommonSuccessResponse(ctx, response)
+}
+
+// All godoc
+// @Tags Report
+// @Summary Get rider report review queue
+// @Description Report with free text message, default PENDING oldest first
+// @Param review query string false "review status" Enums(PENDING, REVIEWED, DISMISSED)
+// @Param busId query int false "bus ID"
+// @Param limit query int false "max report, default 50"
+// @Produce  json
+// @Success 200 {object} dto.GetReviewQueueResponse
+// @Failure 200 {object} dto.GetReviewQueueResponse
+// @Router /report/review [get]
+func (c *Controller) reviewQueue(ctx *fiber.Ctx) error {
+	var (
+		query dto.ReviewQueueQuery
+	)
+
+	err := common.DoCommonQuery(ctx, &query)
+	if err != nil {
+		return common.DoCommonErrorResponse(ctx, err)
+	}
+
+	c.Shared.Logger.Infof("get review queue, data: %v", query)
+
+	res, err := c.Interfaces.ReportViewService.GetReviewQueue(query)
+	if err != nil {
+		return common.DoCommonErrorResponse(ctx, err)
+	}
+
+	return common.DoCommonSuccessResponse(ctx, res)
+}
+
+// All godoc
+// @Tags Report
+// @Summary Review rider report
+// @Description Put all mandatory parameter
+// @Param id path string true "report ID"
+// @Param ReviewReportDto body dto.ReviewReportDto true "ReviewReport"
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} dto.RiderReport
+// @Failure 200 {object} dto.RiderReport
+// @Router /report/review/{id} [put]
+func (c *Controller) review(ctx *fiber.Ctx) error {
+	var (
+		body dto.ReviewReportDto
+	)
+
+	err := common.DoCommonRequest(ctx, &body)
+	if err != nil {
+		return common.DoCommonErrorResponse(ctx, err)
+	}
+
+	id := ctx.Params("id")
+
+	c.Shared.Logger.Infof("review rider report, data: %v, id: %s", body, id)
+
+	res, err := c.Interfaces.ReportViewService.ReviewReport(body, id)
+	if err != nil {
+		return common.DoCommonErrorResponse(ctx, err)
+	}
+
+	return common.DoCommonSuccessResponse(ctx, res)
+}
+
+func NewController(interfaces interfaces.Holder, shared shared.Holder) Controller {
+	return Controller{
+		Interfaces: interfaces,
+		Shared:     shared,
+	}
+}
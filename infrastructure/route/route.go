@@ -0,0 +1,255 @@
+package route
+
+import (
+	"tracking-server/interfaces"
+	"tracking-server/shared"
+	"tracking-server/shared/common"
+	"tracking-server/shared/dto"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type Controller struct {
+	Interfaces interfaces.Holder
+	Shared     shared.Holder
+}
+
+func (c *Controller) Routes(app *fiber.App) {
+	route := app.Group("/route")
+	route.Get("/", c.getAll)
+	route.Get("/:code", c.get)
+	route.Post("/", c.create)
+	route.Put("/:code", c.edit)
+	route.Delete("/:code", c.delete)
+	route.Get("/:code/shape", c.getShape)
+	route.Put("/:code/shape", c.importShape)
+	route.Delete("/:code/shape", c.deleteShape)
+}
+
+// All godoc
+// @Tags Route
+// @Summary Get all route
+// @Description Put all mandatory parameter
+// @Param active query bool false "only active route"
+// @Produce  json
+// @Success 200 {object} dto.GetAllRouteResponse
+// @Failure 200 {object} dto.GetAllRouteResponse
+// @Router /route/ [get]
+func (c *Controller) getAll(ctx *fiber.Ctx) error {
+	var (
+		query dto.GetAllRouteQuery
+	)
+
+	err := common.DoCommonQuery(ctx, &query)
+	if err != nil {
+		return common.DoCommonErrorResponse(ctx, err)
+	}
+
+	c.Shared.Logger.Infof("get all route, data: %v", query)
+
+	res, err := c.Interfaces.RouteViewService.GetAllRoute(query)
+	if err != nil {
+		return common.DoCommonErrorResponse(ctx, err)
+	}
+
+	return common.DoCommonSuccessResponse(ctx, res)
+}
+
+// All godoc
+// @Tags Route
+// @Summary Get route detail
+// @Description Put all mandatory parameter
+// @Param code path string true "route code"
+// @Produce  json
+// @Success 200 {object} dto.BusRoute
+// @Failure 200 {object} dto.BusRoute
+// @Router /route/{code} [get]
+func (c *Controller) get(ctx *fiber.Ctx) error {
+	code := ctx.Params("code")
+
+	c.Shared.Logger.Infof("get route, data: %s", code)
+
+	res, err := c.Interfaces.RouteViewService.GetRoute(code)
+	if err != nil {
+		return common.DoCommonErrorResponse(ctx, err)
+	}
+
+	return common.DoCommonSuccessResponse(ctx, res)
+}
+
+// All godoc
+// @Tags Route
+// @Summary Create new route
+// @Description Code is uppercase alphanumeric, referenced by bus and terminal
+// @Param CreateRouteDto body dto.CreateRouteDto true "CreateRoute"
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} dto.BusRoute
+// @Failure 200 {object} dto.BusRoute
+// @Router /route/ [post]
+func (c *Controller) create(ctx *fiber.Ctx) error {
+	var (
+		body dto.CreateRouteDto
+	)
+
+	err := common.DoCommonRequest(ctx, &body)
+	if err != nil {
+		return common.DoCommonErrorResponse(ctx, err)
+	}
+
+	c.Shared.Logger.Infof("create route, data: %v", body)
+
+	res, err := c.Interfaces.RouteViewService.CreateRoute(body)
+	if err != nil {
+		return common.DoCommonErrorResponse(ctx, err)
+	}
+
+	return common.DoCommonSuccessResponse(ctx, res)
+}
+
+// All godoc
+// @Tags Route
+// @Summary Edit route
+// @Description Put all mandatory parameter
+// @Param code path string true "route code"
+// @Param EditRouteDto body dto.EditRouteDto true "EditRoute"
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} dto.BusRoute
+// @Failure 200 {object} dto.BusRoute
+// @Router /route/{code} [put]
+func (c *Controller) edit(ctx *fiber.Ctx) error {
+	var (
+		body dto.EditRouteDto
+	)
+
+	err := common.DoCommonRequest(ctx, &body)
+	if err != nil {
+		return common.DoCommonErrorResponse(ctx, err)
+	}
+
+	code := ctx.Params("code")
+
+	c.Shared.Logger.Infof("edit route, data: %v, code: %s", body, code)
+
+	res, err := c.Interfaces.RouteViewService.EditRoute(body, code)
+	if err != nil {
+		return common.DoCommonErrorResponse(ctx, err)
+	}
+
+	return common.DoCommonSuccessResponse(ctx, res)
+}
+
+// All godoc
+// @Tags Route
+// @Summary Delete route
+// @Description Route still used by bus or terminal can not be deleted
+// @Param code path string true "route code"
+// @Accept  json
+// @Produce  json
+// @Router /route/{code} [delete]
+func (c *Controller) delete(ctx *fiber.Ctx) error {
+	code := ctx.Params("code")
+
+	c.Shared.Logger.Infof("delete route, data: %s", code)
+
+	err := c.Interfaces.RouteViewService.DeleteRoute(code)
+	if err != nil {
+		return common.DoCommonErrorResponse(ctx, err)
+	}
+
+	return common.DoCommonSuccessResponse(ctx, nil)
+}
+
+// All godoc
+// @Tags Route
+// @Summary Get route shape
+// @Description Shape served as encoded polyline and GeoJSON feature, format limit to one of them
+// @Param code path string true "route code"
+// @Param format query string false "shape format" Enums(polyline, geojson)
+// @Produce  json
+// @Success 200 {object} dto.GetRouteShapeResponse
+// @Failure 200 {object} dto.GetRouteShapeResponse
+// @Router /route/{code}/shape [get]
+func (c *Controller) getShape(ctx *fiber.Ctx) error {
+	var (
+		query dto.GetRouteShapeQuery
+	)
+
+	err := common.DoCommonQuery(ctx, &query)
+	if err != nil {
+		return common.DoCommonErrorResponse(ctx, err)
+	}
+
+	code := ctx.Params("code")
+
+	c.Shared.Logger.Infof("get route shape, data: %v, code: %s", query, code)
+
+	res, err := c.Interfaces.RouteViewService.GetRouteShape(code, query)
+	if err != nil {
+		return common.DoCommonErrorResponse(ctx, err)
+	}
+
+	return common.DoCommonSuccessResponse(ctx, res)
+}
+
+// All godoc
+// @Tags Route
+// @Summary Import route shape
+// @Description Put encoded polyline or GeoJSON LineString, replace the existing shape
+// @Param code path string true "route code"
+// @Param ImportShapeDto body dto.ImportShapeDto true "ImportShape"
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} dto.GetRouteShapeResponse
+// @Failure 200 {object} dto.GetRouteShapeResponse
+// @Router /route/{code}/shape [put]
+func (c *Controller) importShape(ctx *fiber.Ctx) error {
+	var (
+		body dto.ImportShapeDto
+	)
+
+	err := common.DoCommonRequest(ctx, &body)
+	if err != nil {
+		return common.DoCommonErrorResponse(ctx, err)
+	}
+
+	code := ctx.Params("code")
+
+	c.Shared.Logger.Infof("import route shape, code: %s", code)
+
+	res, err := c.Interfaces.RouteViewService.ImportRouteShape(code, body)
+	if err != nil {
+		return common.DoCommonErrorResponse(ctx, err)
+	}
+
+	return common.DoCommonSuccessResponse(ctx, res)
+}
+
+// All godoc
+// @Tags Route
+// @Summary Delete route shape
+// @Description Put all mandatory parameter
+// @Param code path string true "route code"
+// @Accept  json
+// @Produce  json
+// @Router /route/{code}/shape [delete]
+func (c *Controller) deleteShape(ctx *fiber.Ctx) error {
+	code := ctx.Params("code")
+
+	c.Shared.Logger.Infof("delete route shape, code: %s", code)
+
+	err := c.Interfaces.RouteViewService.DeleteRouteShape(code)
+	if err != nil {
+		return common.DoCommonErrorResponse(ctx, err)
+	}
+
+	return common.DoCommonSuccessResponse(ctx, nil)
+}
+
+func NewController(interfaces interfaces.Holder, shared shared.Holder) Controller {
+	return Controller{
+		Interfaces: interfaces,
+		Shared:     shared,
+	}
+}
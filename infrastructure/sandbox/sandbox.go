@@ -0,0 +1,167 @@
+package sandbox
+
+import (
+	"tracking-server/interfaces"
+	"tracking-server/shared"
+	"tracking-server/shared/common"
+	"tracking-server/shared/dto"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type Controller struct {
+	Interfaces interfaces.Holder
+	Shared     shared.Holder
+}
+
+func (c *Controller) Routes(app *fiber.App) {
+	sandbox := app.Group("/sandbox")
+	sandbox.Post("/", c.create)
+	sandbox.Delete("/:name", c.delete)
+	sandbox.Get("/:name/bus", c.getBus)
+	sandbox.Put("/:name/bus", c.linkBus)
+	sandbox.Delete("/:name/bus/:experimentalId", c.unlinkBus)
+}
+
+// All godoc
+// @Tags Sandbox
+// @Summary Create new sandbox for experimental tracking
+// @Description Token only returned once, use it as sandboxToken on stream
+// @Param CreateSandboxDto body dto.CreateSandboxDto true "CreateSandboxDto"
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} dto.CreateSandboxResponse
+// @Failure 200 {object} dto.CreateSandboxResponse
+// @Router /sandbox/ [post]
+func (c *Controller) create(ctx *fiber.Ctx) error {
+	var (
+		body     dto.CreateSandboxDto
+		response dto.CreateSandboxResponse
+	)
+
+	err := common.DoCommonRequest(ctx, &body)
+	if err != nil {
+		return common.DoCommonErrorResponse(ctx, err)
+	}
+
+	c.Shared.Logger.Infof("create sandbox, data: %v", body)
+
+	response, err = c.Interfaces.SandboxViewService.CreateSandbox(body)
+	if err != nil {
+		return common.DoCommonErrorResponse(ctx, err)
+	}
+
+	return common.DoCommonSuccessResponse(ctx, response)
+}
+
+// All godoc
+// @Tags Sandbox
+// @Summary Delete sandbox
+// @Description Put all mandatory parameter
+// @Param name path string true "Sandbox name"
+// @Param auth header string true "sandbox token"
+// @Accept  json
+// @Produce  json
+// @Router /sandbox/{name} [delete]
+func (c *Controller) delete(ctx *fiber.Ctx) error {
+	name := ctx.Params("name")
+
+	c.Shared.Logger.Infof("delete sandbox, data: %s", name)
+
+	err := c.Interfaces.SandboxViewService.DeleteSandbox(name, ctx.Get("auth"))
+	if err != nil {
+		return common.DoCommonErrorResponse(ctx, err)
+	}
+
+	return common.DoCommonSuccessResponse(ctx, nil)
+}
+
+// All godoc
+// @Tags Sandbox
+// @Summary Get bus linked in sandbox
+// @Description Put all mandatory parameter
+// @Param name path string true "Sandbox name"
+// @Param auth header string true "sandbox token"
+// @Accept  json
+// @Produce  json
+// @Success 200 {array} dto.SandboxBusResponse
+// @Failure 200 {array} dto.SandboxBusResponse
+// @Router /sandbox/{name}/bus [get]
+func (c *Controller) getBus(ctx *fiber.Ctx) error {
+	name := ctx.Params("name")
+
+	c.Shared.Logger.Infof("get sandbox bus, data: %s", name)
+
+	response, err := c.Interfaces.SandboxViewService.GetSandboxBus(name, ctx.Get("auth"))
+	if err != nil {
+		return common.DoCommonErrorResponse(ctx, err)
+	}
+
+	return common.DoCommonSuccessResponse(ctx, response)
+}
+
+// All godoc
+// @Tags Sandbox
+// @Summary Link experimental bus to bus metadata
+// @Description Put all mandatory parameter
+// @Param name path string true "Sandbox name"
+// @Param auth header string true "sandbox token"
+// @Param LinkSandboxBusDto body dto.LinkSandboxBusDto true "LinkSandboxBusDto"
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} dto.SandboxBusResponse
+// @Failure 200 {object} dto.SandboxBusResponse
+// @Router /sandbox/{name}/bus [put]
+func (c *Controller) linkBus(ctx *fiber.Ctx) error {
+	var (
+		body     dto.LinkSandboxBusDto
+		response dto.SandboxBusResponse
+	)
+
+	err := common.DoCommonRequest(ctx, &body)
+	if err != nil {
+		return common.DoCommonErrorResponse(ctx, err)
+	}
+
+	name := ctx.Params("name")
+
+	c.Shared.Logger.Infof("link sandbox bus, data: %v, name: %s", body, name)
+
+	response, err = c.Interfaces.SandboxViewService.LinkSandboxBus(body, name, ctx.Get("auth"))
+	if err != nil {
+		return common.DoCommonErrorResponse(ctx, err)
+	}
+
+	return common.DoCommonSuccessResponse(ctx, response)
+}
+
+// All godoc
+// @Tags Sandbox
+// @Summary Unlink experimental bus
+// @Description Put all mandatory parameter
+// @Param name path string true "Sandbox name"
+// @Param experimentalId path string true "Experimental bus ID"
+// @Param auth header string true "sandbox token"
+// @Accept  json
+// @Produce  json
+// @Router /sandbox/{name}/bus/{experimentalId} [delete]
+func (c *Controller) unlinkBus(ctx *fiber.Ctx) error {
+	name := ctx.Params("name")
+	experimentalID := ctx.Params("experimentalId")
+
+	c.Shared.Logger.Infof("unlink sandbox bus, name: %s, experimentalId: %s", name, experimentalID)
+
+	err := c.Interfaces.SandboxViewService.UnlinkSandboxBus(name, experimentalID, ctx.Get("auth"))
+	if err != nil {
+		return common.DoCommonErrorResponse(ctx, err)
+	}
+
+	return common.DoCommonSuccessResponse(ctx, nil)
+}
+
+func NewController(interfaces interfaces.Holder, shared shared.Holder) Controller {
+	return Controller{
+		Interfaces: interfaces,
+		Shared:     shared,
+	}
+}
@@ -0,0 +1,169 @@
+package schedule
+
+import (
+	"tracking-server/interfaces"
+	"tracking-server/shared"
+	"tracking-server/shared/common"
+	"tracking-server/shared/dto"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type Controller struct {
+	Interfaces interfaces.Holder
+	Shared     shared.Holder
+}
+
+func (c *Controller) Routes(app *fiber.App) {
+	schedule := app.Group("/schedule")
+	schedule.Get("/", c.get)
+	schedule.Get("/status", c.status)
+	schedule.Post("/window", c.createWindow)
+	schedule.Delete("/window/:id", c.deleteWindow)
+	schedule.Post("/exception", c.createException)
+	schedule.Delete("/exception/:id", c.deleteException)
+}
+
+// All godoc
+// @Tags Schedule
+// @Summary Get operating window and service exception
+// @Description Put all mandatory parameter
+// @Produce  json
+// @Success 200 {object} dto.GetScheduleResponse
+// @Failure 200 {object} dto.GetScheduleResponse
+// @Router /schedule/ [get]
+func (c *Controller) get(ctx *fiber.Ctx) error {
+	c.Shared.Logger.Infof("get schedule")
+
+	res, err := c.Interfaces.ScheduleViewService.GetSchedule()
+	if err != nil {
+		return common.DoCommonErrorResponse(ctx, err)
+	}
+
+	return common.DoCommonSuccessResponse(ctx, res)
+}
+
+// All godoc
+// @Tags Schedule
+// @Summary Get service status of every route
+// @Description Whether each route is operating now and when it next starts
+// @Produce  json
+// @Success 200 {object} dto.GetServiceStatusResponse
+// @Failure 200 {object} dto.GetServiceStatusResponse
+// @Router /schedule/status [get]
+func (c *Controller) status(ctx *fiber.Ctx) error {
+	c.Shared.Logger.Infof("get service status")
+
+	res := c.Interfaces.ScheduleViewService.GetServiceStatus()
+
+	return common.DoCommonSuccessResponse(ctx, res)
+}
+
+// All godoc
+// @Tags Schedule
+// @Summary Create operating window
+// @Description Start and end in HH:MM WIB, BREAK window used during semester break
+// @Param CreateOperatingWindowDto body dto.CreateOperatingWindowDto true "CreateOperatingWindow"
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} dto.OperatingWindow
+// @Failure 200 {object} dto.OperatingWindow
+// @Router /schedule/window [post]
+func (c *Controller) createWindow(ctx *fiber.Ctx) error {
+	var (
+		body dto.CreateOperatingWindowDto
+	)
+
+	err := common.DoCommonRequest(ctx, &body)
+	if err != nil {
+		return common.DoCommonErrorResponse(ctx, err)
+	}
+
+	c.Shared.Logger.Infof("create operating window, data: %v", body)
+
+	res, err := c.Interfaces.ScheduleViewService.CreateWindow(body)
+	if err != nil {
+		return common.DoCommonErrorResponse(ctx, err)
+	}
+
+	return common.DoCommonSuccessResponse(ctx, res)
+}
+
+// All godoc
+// @Tags Schedule
+// @Summary Delete operating window
+// @Description Put all mandatory parameter
+// @Param id path string true "window ID"
+// @Accept  json
+// @Produce  json
+// @Router /schedule/window/{id} [delete]
+func (c *Controller) deleteWindow(ctx *fiber.Ctx) error {
+	id := ctx.Params("id")
+
+	c.Shared.Logger.Infof("delete operating window, data: %s", id)
+
+	err := c.Interfaces.ScheduleViewService.DeleteWindow(id)
+	if err != nil {
+		return common.DoCommonErrorResponse(ctx, err)
+	}
+
+	return common.DoCommonSuccessResponse(ctx, nil)
+}
+
+// All godoc
+// @Tags Schedule
+// @Summary Create service exception
+// @Description Holiday stop the service, semester break use BREAK window, empty route applies to every route
+// @Param CreateServiceExceptionDto body dto.CreateServiceExceptionDto true "CreateServiceException"
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} dto.ServiceException
+// @Failure 200 {object} dto.ServiceException
+// @Router /schedule/exception [post]
+func (c *Controller) createException(ctx *fiber.Ctx) error {
+	var (
+		body dto.CreateServiceExceptionDto
+	)
+
+	err := common.DoCommonRequest(ctx, &body)
+	if err != nil {
+		return common.DoCommonErrorResponse(ctx, err)
+	}
+
+	c.Shared.Logger.Infof("create service exception, data: %v", body)
+
+	res, err := c.Interfaces.ScheduleViewService.CreateException(body)
+	if err != nil {
+		return common.DoCommonErrorResponse(ctx, err)
+	}
+
+	return common.DoCommonSuccessResponse(ctx, res)
+}
+
+// All godoc
+// @Tags Schedule
+// @Summary Delete service exception
+// @Description Put all mandatory parameter
+// @Param id path string true "exception ID"
+// @Accept  json
+// @Produce  json
+// @Router /schedule/exception/{id} [delete]
+func (c *Controller) deleteException(ctx *fiber.Ctx) error {
+	id := ctx.Params("id")
+
+	c.Shared.Logger.Infof("delete service exception, data: %s", id)
+
+	err := c.Interfaces.ScheduleViewService.DeleteException(id)
+	if err != nil {
+		return common.DoCommonErrorResponse(ctx, err)
+	}
+
+	return common.DoCommonSuccessResponse(ctx, nil)
+}
+
+func NewController(interfaces interfaces.Holder, shared shared.Holder) Controller {
+	return Controller{
+		Interfaces: interfaces,
+		Shared:     shared,
+	}
+}
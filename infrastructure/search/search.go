@@ -0,0 +1,61 @@
+package search
+
+import (
+	"tracking-server/interfaces"
+	"tracking-server/shared"
+	"tracking-server/shared/common"
+	"tracking-server/shared/dto"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type Controller struct {
+	Interfaces interfaces.Holder
+	Shared     shared.Holder
+}
+
+func (c *Controller) Routes(app *fiber.App) {
+	search := app.Group("/search")
+	search.Get("/", c.search)
+}
+
+// All godoc
+// @Tags Search
+// @Summary Search terminal, place and news
+// @Description Prefix, alias and typo tolerant match, rider location rank nearby result higher
+// @Param q query string true "search text"
+// @Param type query string false "result type" Enums(terminal, place, news)
+// @Param lat query number false "rider latitude"
+// @Param long query number false "rider longitude"
+// @Param limit query int false "max result returned"
+// @Param lang query string false "response language" Enums(id, en)
+// @Produce  json
+// @Success 200 {object} dto.SearchResponse
+// @Failure 200 {object} dto.SearchResponse
+// @Router /search/ [get]
+func (c *Controller) search(ctx *fiber.Ctx) error {
+	var (
+		query dto.SearchQuery
+	)
+
+	err := common.DoCommonQuery(ctx, &query)
+	if err != nil {
+		return common.DoCommonErrorResponse(ctx, err)
+	}
+
+	c.Shared.Logger.Infof("search, data: %v", query)
+
+	res, err := c.Interfaces.SearchViewService.Search(query, common.GetLocale(ctx))
+	if err != nil {
+		return common.DoCommonErrorResponse(ctx, err)
+	}
+
+	return common.DoCommonSuccessResponse(ctx, res)
+}
+
+func NewController(interfaces interfaces.Holder, shared shared.Holder) Controller {
+	return Controller{
+		Interfaces: interfaces,
+		Shared:     shared,
+	}
+}
@@ -0,0 +1,107 @@
+package station
+
+import (
+	"tracking-server/interfaces"
+	"tracking-server/shared"
+	"tracking-server/shared/common"
+	"tracking-server/shared/dto"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type Controller struct {
+	Interfaces interfaces.Holder
+	Shared     shared.Holder
+}
+
+func (c *Controller) Routes(app *fiber.App) {
+	station := app.Group("/station")
+	station.Get("/", c.getAll)
+	station.Get("/:id", c.get)
+	station.Get("/:id/arrival", c.arrival)
+}
+
+// All godoc
+// @Tags Station
+// @Summary Get all station
+// @Description Terminal sharing the same name on different route grouped into one station
+// @Param lat query number false "rider latitude"
+// @Param long query number false "rider longitude"
+// @Param limit query int false "max station returned"
+// @Param lang query string false "response language" Enums(id, en)
+// @Produce  json
+// @Success 200 {object} dto.GetAllStationResponse
+// @Failure 200 {object} dto.GetAllStationResponse
+// @Router /station/ [get]
+func (c *Controller) getAll(ctx *fiber.Ctx) error {
+	var (
+		query dto.GetAllStationQuery
+	)
+
+	err := common.DoCommonQuery(ctx, &query)
+	if err != nil {
+		return common.DoCommonErrorResponse(ctx, err)
+	}
+
+	c.Shared.Logger.Infof("get all station, data: %v", query)
+
+	res, err := c.Interfaces.StationViewService.GetAllStation(query, common.GetLocale(ctx))
+	if err != nil {
+		return common.DoCommonErrorResponse(ctx, err)
+	}
+
+	return common.DoCommonSuccessResponse(ctx, res)
+}
+
+// All godoc
+// @Tags Station
+// @Summary Get station detail
+// @Description Stop of each route with the bus arriving at each stop
+// @Param id path string true "station id"
+// @Param lang query string false "response language" Enums(id, en)
+// @Produce  json
+// @Success 200 {object} dto.StationResponse
+// @Failure 200 {object} dto.StationResponse
+// @Router /station/{id} [get]
+func (c *Controller) get(ctx *fiber.Ctx) error {
+	id := ctx.Params("id")
+
+	c.Shared.Logger.Infof("get station, data: %s", id)
+
+	res, err := c.Interfaces.StationViewService.GetStation(id, common.GetLocale(ctx))
+	if err != nil {
+		return common.DoCommonErrorResponse(ctx, err)
+	}
+
+	return common.DoCommonSuccessResponse(ctx, res)
+}
+
+// All godoc
+// @Tags Station
+// @Summary Get combined arrival of a station
+// @Description Next bus of every route stopping at the station sorted by estimate
+// @Param id path string true "station id"
+// @Param lang query string false "response language" Enums(id, en)
+// @Produce  json
+// @Success 200 {object} dto.StationArrivalResponse
+// @Failure 200 {object} dto.StationArrivalResponse
+// @Router /station/{id}/arrival [get]
+func (c *Controller) arrival(ctx *fiber.Ctx) error {
+	id := ctx.Params("id")
+
+	c.Shared.Logger.Infof("get station arrival, data: %s", id)
+
+	res, err := c.Interfaces.StationViewService.GetStationArrival(id, common.GetLocale(ctx))
+	if err != nil {
+		return common.DoCommonErrorResponse(ctx, err)
+	}
+
+	return common.DoCommonSuccessResponse(ctx, res)
+}
+
+func NewController(interfaces interfaces.Holder, shared shared.Holder) Controller {
+	return Controller{
+		Interfaces: interfaces,
+		Shared:     shared,
+	}
+}
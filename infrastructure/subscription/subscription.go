@@ -0,0 +1,108 @@
+package subscription
+
+import (
+	"tracking-server/interfaces"
+	"tracking-server/shared"
+	"tracking-server/shared/common"
+	"tracking-server/shared/dto"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/websocket/v2"
+)
+
+type Controller struct {
+	Interfaces interfaces.Holder
+	Shared     shared.Holder
+}
+
+func (c *Controller) Routes(app *fiber.App) {
+	subscription := app.Group("/subscription")
+	subscription.Post("/", c.create)
+	subscription.Delete("/:key", c.delete)
+
+	subscription.Use("/stream", func(ctx *fiber.Ctx) error {
+		if websocket.IsWebSocketUpgrade(ctx) {
+			return ctx.Next()
+		}
+		return fiber.ErrUpgradeRequired
+	})
+	subscription.Get("/stream/:key", websocket.New(c.streamNotification))
+}
+
+// All godoc
+// @Tags Subscription
+// @Summary Subscribe to bus arrival alert
+// @Description Notify once when a bus is within threshold minute of the terminal, recipient required for WEBHOOK channel
+// @Param CreateSubscriptionDto body dto.CreateSubscriptionDto true "CreateSubscription"
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} dto.CreateSubscriptionResponse
+// @Failure 200 {object} dto.CreateSubscriptionResponse
+// @Router /subscription/ [post]
+func (c *Controller) create(ctx *fiber.Ctx) error {
+	var (
+		body     dto.CreateSubscriptionDto
+		response dto.CreateSubscriptionResponse
+	)
+
+	err := common.DoCommonRequest(ctx, &body)
+	if err != nil {
+		return common.DoCommonErrorResponse(ctx, err)
+	}
+
+	c.Shared.Logger.Infof("create subscription, data: %v", body)
+
+	response, err = c.Interfaces.SubscriptionViewService.CreateSubscription(body)
+	if err != nil {
+		return common.DoCommonErrorResponse(ctx, err)
+	}
+
+	return common.DoCommonSuccessResponse(ctx, response)
+}
+
+// All godoc
+// @Tags Subscription
+// @Summary Cancel bus arrival alert
+// @Description Put all mandatory parameter
+// @Param key path string true "subscription key"
+// @Accept  json
+// @Produce  json
+// @Router /subscription/{key} [delete]
+func (c *Controller) delete(ctx *fiber.Ctx) error {
+	key := ctx.Params("key")
+
+	c.Shared.Logger.Infof("delete subscription, data: %s", key)
+
+	err := c.Interfaces.SubscriptionViewService.DeleteSubscription(key)
+	if err != nil {
+		return common.DoCommonErrorResponse(ctx, err)
+	}
+
+	return common.DoCommonSuccessResponse(ctx, nil)
+}
+
+/**
+ * Send arrival notification of a subscription once using websocket
+ * @param key subscription key
+ */
+func (c *Controller) streamNotification(ctx *websocket.Conn) {
+	defer func() {
+		ctx.Close()
+	}()
+
+	key := ctx.Params("key")
+
+	c.Shared.Logger.Infof("stream subscription notification, data: %s", key)
+
+	err := c.Interfaces.SubscriptionViewService.StreamNotification(key, ctx)
+	if err != nil {
+		ctx.WriteJSON(common.Response{Status: "FAILED", Error: err.Error()})
+	}
+}
+
+func NewController(interfaces interfaces.Holder, shared shared.Holder) Controller {
+	return Controller{
+		Interfaces: interfaces,
+		Shared:     shared,
+	}
+}
@@ -16,9 +16,15 @@ type Controller struct {
 
 func (c *Controller) Routes(app *fiber.App) {
 	terminal := app.Group("/terminal")
+	terminal.Get("/nearest", c.nearest)
 	terminal.Get("/:id", c.get)
 	terminal.Post("/allTerminal", c.allTerminal)
 	terminal.Post("/twoClosest", c.twoClosestTerminal)
+	terminal.Get("/route/:code", c.routeTerminal)
+	terminal.Post("/", c.create)
+	terminal.Put("/reorder", c.reorder)
+	terminal.Put("/:id", c.edit)
+	terminal.Delete("/:id", c.delete)
 }
 
 // All godoc
@@ -26,6 +32,7 @@ func (c *Controller) Routes(app *fiber.App) {
 // @Summary Get terminal info
 // @Description Put all mandatory parameter
 // @Param id path string true "terminal ID"
+// @Param lang query string false "response language" Enums(id, en)
 // @Accept  json
 // @Produce  json
 // @Success 200 {object} dto.GetTerminalInfoResponse
@@ -36,7 +43,41 @@ func (c *Controller) get(ctx *fiber.Ctx) error {
 
 	c.Shared.Logger.Infof("get terminal info, data: %s", id)
 
-	res, err := c.Interfaces.TerminalViewsService.GetTerminalInfo(id)
+	res, err := c.Interfaces.TerminalViewsService.GetTerminalInfo(id, common.GetLocale(ctx))
+	if err != nil {
+		return common.DoCommonErrorResponse(ctx, err)
+	}
+
+	return common.DoCommonSuccessResponse(ctx, res)
+}
+
+// All godoc
+// @Tags Terminal
+// @Summary Get nearest terminal
+// @Description Terminal sorted by distance with walking estimate, next terminal follow each route
+// @Param lat query number true "rider latitude"
+// @Param long query number true "rider longitude"
+// @Param radius query number false "max distance in km"
+// @Param route query string false "route code"
+// @Param limit query int false "max terminal returned"
+// @Param lang query string false "response language" Enums(id, en)
+// @Produce  json
+// @Success 200 {object} dto.GetAllTerminalResponse
+// @Failure 200 {object} dto.GetAllTerminalResponse
+// @Router /terminal/nearest [get]
+func (c *Controller) nearest(ctx *fiber.Ctx) error {
+	var (
+		query dto.NearestTerminalQuery
+	)
+
+	err := common.DoCommonQuery(ctx, &query)
+	if err != nil {
+		return common.DoCommonErrorResponse(ctx, err)
+	}
+
+	c.Shared.Logger.Infof("get nearest terminal, data: %v", query)
+
+	res, err := c.Interfaces.TerminalViewsService.GetNearestTerminal(query, common.GetLocale(ctx))
 	if err != nil {
 		return common.DoCommonErrorResponse(ctx, err)
 	}
@@ -47,8 +88,10 @@ func (c *Controller) get(ctx *fiber.Ctx) error {
 // All godoc
 // @Tags Terminal
 // @Summary Get all terminal sorted by distance
-// @Description Put all mandatory parameter
+// @Description Deprecated, use GET /terminal/nearest instead
+// @Deprecated
 // @Param GetAllTerminalDto body dto.GetAllTerminalDto true "GetAllTerminalDto"
+// @Param lang query string false "response language" Enums(id, en)
 // @Accept  json
 // @Produce  json
 // @Success 200 {object} dto.GetAllTerminalResponse
@@ -67,7 +110,7 @@ func (c *Controller) allTerminal(ctx *fiber.Ctx) error {
 
 	c.Shared.Logger.Infof("get all terminal, data: %s", body)
 
-	response, err = c.Interfaces.TerminalViewsService.GetAllTerminalSorted(body)
+	response, err = c.Interfaces.TerminalViewsService.GetAllTerminalSorted(body, common.GetLocale(ctx))
 	if err != nil {
 		return common.DoCommonErrorResponse(ctx, err)
 	}
@@ -77,9 +120,11 @@ func (c *Controller) allTerminal(ctx *fiber.Ctx) error {
 
 // All godoc
 // @Tags Terminal
-// @Summary Get two closestterminal
-// @Description Put all mandatory parameter
+// @Summary Get two closest terminal
+// @Description Deprecated, use GET /terminal/nearest with limit instead
+// @Deprecated
 // @Param GetAllTerminalDto body dto.GetAllTerminalDto true "GetAllTerminalDto"
+// @Param lang query string false "response language" Enums(id, en)
 // @Accept  json
 // @Produce  json
 // @Success 200 {object} dto.GetAllTerminalResponse
@@ -98,7 +143,7 @@ func (c *Controller) twoClosestTerminal(ctx *fiber.Ctx) error {
 
 	c.Shared.Logger.Infof("get all terminal, data: %s", body)
 
-	response, err = c.Interfaces.TerminalViewsService.GetTwoClosesTerminal(body)
+	response, err = c.Interfaces.TerminalViewsService.GetTwoClosesTerminal(body, common.GetLocale(ctx))
 	if err != nil {
 		return common.DoCommonErrorResponse(ctx, err)
 	}
@@ -106,6 +151,143 @@ func (c *Controller) twoClosestTerminal(ctx *fiber.Ctx) error {
 	return common.DoCommonSuccessResponse(ctx, response)
 }
 
+// All godoc
+// @Tags Terminal
+// @Summary Get terminal of a route ordered by sequence
+// @Description Put all mandatory parameter
+// @Param code path string true "route code"
+// @Param lang query string false "response language" Enums(id, en)
+// @Produce  json
+// @Success 200 {object} dto.GetRouteTerminalResponse
+// @Failure 200 {object} dto.GetRouteTerminalResponse
+// @Router /terminal/route/{code} [get]
+func (c *Controller) routeTerminal(ctx *fiber.Ctx) error {
+	code := ctx.Params("code")
+
+	c.Shared.Logger.Infof("get route terminal, data: %s", code)
+
+	res, err := c.Interfaces.TerminalViewsService.GetRouteTerminal(dto.Route(code), common.GetLocale(ctx))
+	if err != nil {
+		return common.DoCommonErrorResponse(ctx, err)
+	}
+
+	return common.DoCommonSuccessResponse(ctx, res)
+}
+
+// All godoc
+// @Tags Terminal
+// @Summary Create new terminal
+// @Description Empty sequence append the terminal at the end of the route
+// @Param CreateTerminalDto body dto.CreateTerminalDto true "CreateTerminal"
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} dto.TerminalResponse
+// @Failure 200 {object} dto.TerminalResponse
+// @Router /terminal/ [post]
+func (c *Controller) create(ctx *fiber.Ctx) error {
+	var (
+		body dto.CreateTerminalDto
+	)
+
+	err := common.DoCommonRequest(ctx, &body)
+	if err != nil {
+		return common.DoCommonErrorResponse(ctx, err)
+	}
+
+	c.Shared.Logger.Infof("create terminal, data: %v", body)
+
+	res, err := c.Interfaces.TerminalViewsService.CreateTerminal(body)
+	if err != nil {
+		return common.DoCommonErrorResponse(ctx, err)
+	}
+
+	return common.DoCommonSuccessResponse(ctx, res)
+}
+
+// All godoc
+// @Tags Terminal
+// @Summary Reorder terminal of a route
+// @Description Terminal ids must contain every terminal of the route in the new order
+// @Param ReorderTerminalDto body dto.ReorderTerminalDto true "ReorderTerminal"
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} dto.GetRouteTerminalResponse
+// @Failure 200 {object} dto.GetRouteTerminalResponse
+// @Router /terminal/reorder [put]
+func (c *Controller) reorder(ctx *fiber.Ctx) error {
+	var (
+		body dto.ReorderTerminalDto
+	)
+
+	err := common.DoCommonRequest(ctx, &body)
+	if err != nil {
+		return common.DoCommonErrorResponse(ctx, err)
+	}
+
+	c.Shared.Logger.Infof("reorder terminal, data: %v", body)
+
+	res, err := c.Interfaces.TerminalViewsService.ReorderTerminal(body)
+	if err != nil {
+		return common.DoCommonErrorResponse(ctx, err)
+	}
+
+	return common.DoCommonSuccessResponse(ctx, res)
+}
+
+// All godoc
+// @Tags Terminal
+// @Summary Edit terminal
+// @Description Put all mandatory parameter
+// @Param id path string true "terminal ID"
+// @Param EditTerminalDto body dto.EditTerminalDto true "EditTerminal"
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} dto.TerminalResponse
+// @Failure 200 {object} dto.TerminalResponse
+// @Router /terminal/{id} [put]
+func (c *Controller) edit(ctx *fiber.Ctx) error {
+	var (
+		body dto.EditTerminalDto
+	)
+
+	err := common.DoCommonRequest(ctx, &body)
+	if err != nil {
+		return common.DoCommonErrorResponse(ctx, err)
+	}
+
+	id := ctx.Params("id")
+
+	c.Shared.Logger.Infof("edit terminal, data: %v, id: %s", body, id)
+
+	res, err := c.Interfaces.TerminalViewsService.EditTerminal(body, id)
+	if err != nil {
+		return common.DoCommonErrorResponse(ctx, err)
+	}
+
+	return common.DoCommonSuccessResponse(ctx, res)
+}
+
+// All godoc
+// @Tags Terminal
+// @Summary Delete terminal
+// @Description Terminal after it on the route moved forward
+// @Param id path string true "terminal ID"
+// @Accept  json
+// @Produce  json
+// @Router /terminal/{id} [delete]
+func (c *Controller) delete(ctx *fiber.Ctx) error {
+	id := ctx.Params("id")
+
+	c.Shared.Logger.Infof("delete terminal, data: %s", id)
+
+	err := c.Interfaces.TerminalViewsService.DeleteTerminal(id)
+	if err != nil {
+		return common.DoCommonErrorResponse(ctx, err)
+	}
+
+	return common.DoCommonSuccessResponse(ctx, nil)
+}
+
 func NewController(interfaces interfaces.Holder, shared shared.Holder) Controller {
 	return Controller{
 		Interfaces: interfaces,
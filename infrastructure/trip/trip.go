@@ -0,0 +1,59 @@
+package trip
+
+import (
+	"tracking-server/interfaces"
+	"tracking-server/shared"
+	"tracking-server/shared/common"
+	"tracking-server/shared/dto"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type Controller struct {
+	Interfaces interfaces.Holder
+	Shared     shared.Holder
+}
+
+func (c *Controller) Routes(app *fiber.App) {
+	trip := app.Group("/trip")
+	trip.Post("/plan", c.plan)
+}
+
+// All godoc
+// @Tags Trip
+// @Summary Plan trip to a terminal or landmark
+// @Description Put origin coordinate and either terminalId or destination name
+// @Param PlanTripDto body dto.PlanTripDto true "PlanTripDto"
+// @Param lang query string false "response language" Enums(id, en)
+// @Accept  json
+// @Produce  json
+// @Success 200 {object} dto.PlanTripResponse
+// @Failure 200 {object} dto.PlanTripResponse
+// @Router /trip/plan [post]
+func (c *Controller) plan(ctx *fiber.Ctx) error {
+	var (
+		body     dto.PlanTripDto
+		response dto.PlanTripResponse
+	)
+
+	err := common.DoCommonRequest(ctx, &body)
+	if err != nil {
+		return common.DoCommonErrorResponse(ctx, err)
+	}
+
+	c.Shared.Logger.Infof("plan trip, data: %v", body)
+
+	response, err = c.Interfaces.TripViewService.PlanTrip(body, common.GetLocale(ctx))
+	if err != nil {
+		return common.DoCommonErrorResponse(ctx, err)
+	}
+
+	return common.DoCommonSuccessResponse(ctx, response)
+}
+
+func NewController(interfaces interfaces.Holder, shared shared.Holder) Controller {
+	return Controller{
+		Interfaces: interfaces,
+		Shared:     shared,
+	}
+}
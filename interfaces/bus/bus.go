@@ -2,6 +2,8 @@ package bus
 
 import (
 	"context"
+	"errors"
+	"math"
 	"sort"
 	"strconv"
 	"time"
@@ -24,8 +26,11 @@ type (
 		EditBus(data dto.EditBusDto, id string, token string) (dto.EditBusResponse, error)
 		TrackBusLocation(query dto.BusLocationQuery, c *websocket.Conn) (dto.BusLocationMessage, error)
 		StreamBusLocation(query dto.BusLocationQuery) []dto.TrackLocationResponse
-		BusInfo(id string) (dto.BusInfoResponse, error)
+		StreamMessage(query dto.BusLocationQuery, bus []dto.TrackLocationResponse) interface{}
+		BusInfo(id string, locale common.Locale) (dto.BusInfoResponse, error)
+		UpcomingStop(id string) (dto.UpcomingStopResponse, error)
 		TrackBusLocationFirebase(query dto.BusLocationQuery, c *websocket.Conn, client *firestore.Client, firebaseCtx context.Context) (dto.BusLocationMessage, error)
+		StreamBusLocationFirebase(query dto.BusLocationQuery, c *websocket.Conn, client *firestore.Client, firebaseCtx context.Context) error
 	}
 	viewService struct {
 		application application.Holder
@@ -180,6 +185,8 @@ func (v *viewService) EditBus(data dto.EditBusDto, id string, token string) (dto
 		return response, err
 	}
 
+	previous := bus.Status
+
 	bus.FillBusEdit(data)
 
 	err = v.application.BusService.Save(bus)
@@ -188,6 +195,10 @@ func (v *viewService) EditBus(data dto.EditBusDto, id string, token string) (dto
 		return response, err
 	}
 
+	if bus.Status != previous {
+		v.recordCrowdStatus(*bus, previous)
+	}
+
 	response = bus.ToEditBusResponnse()
 
 	return response, nil
@@ -195,7 +206,7 @@ func (v *viewService) EditBus(data dto.EditBusDto, id string, token string) (dto
 
 /**
  * Stote bus latest location received from web socket
- * * if the request is using experimental tracking, store it in local map
+ * * if the request is using experimental tracking, store it in sandbox fleet state
  * Bus location store asynchronously
  */
 func (v *viewService) TrackBusLocation(query dto.BusLocationQuery, c *websocket.Conn) (dto.BusLocationMessage, error) {
@@ -238,12 +249,15 @@ func (v *viewService) TrackBusLocation(query dto.BusLocationQuery, c *websocket.
 		v.shared.Logger.Infof("insert bus location, data: %s", location)
 	}()
 
+	// processed in order of arrival so progress and geofence never see an older location last
+	v.processLocation(bus, location)
+
 	return data, nil
 }
 
 /**
  * Send the latest bus location record to websocket client
- * * if using experimental tracking, get data from local map instead
+ * * if using experimental tracking, get data from sandbox fleet state instead
  */
 func (v *viewService) StreamBusLocation(query dto.BusLocationQuery) []dto.TrackLocationResponse {
 	var (
@@ -251,7 +265,7 @@ func (v *viewService) StreamBusLocation(query dto.BusLocationQuery) []dto.TrackL
 	)
 
 	if query.Experimental == "true" {
-		return v.streamBusLocationExperimental()
+		return v.streamBusLocationExperimental(query)
 	}
 
 	response = v.getBusLatestLocation()
@@ -261,13 +275,18 @@ func (v *viewService) StreamBusLocation(query dto.BusLocationQuery) []dto.TrackL
 
 /**
  * Get bus estimation time to a terminal
- * Get latest bus location data and then calculate the estimation
+ * Get latest bus location data and then calculate the estimation along the route
+ * Bus on other route than the terminal excluded
+ * Bus that just passed the terminal estimated for its next lap
+ * Estimation use historical segment travel time, fallback to bus speed
  * Sort the estimation from the fastest to slowest
+ * Terminal closed at the moment is not served, only the closure reason returned
  */
-func (v *viewService) BusInfo(id string) (dto.BusInfoResponse, error) {
+func (v *viewService) BusInfo(id string, locale common.Locale) (dto.BusInfoResponse, error) {
 	var (
 		res               dto.BusInfoResponse
 		terminal          = dto.Terminal{}
+		terminals         = []dto.Terminal{}
 		busLatestLocation []dto.TrackLocationResponse
 		busInfo           = make([]dto.BusInfo, 0)
 	)
@@ -278,19 +297,46 @@ func (v *viewService) BusInfo(id string) (dto.BusInfoResponse, error) {
 		return res, err
 	}
 
+	err = v.application.TerminalService.GetAllByRoute(terminal.Route, &terminals)
+	if err != nil {
+		v.shared.Logger.Errorf("error when finding all terminal by route, err: %s", err.Error())
+		return res, err
+	}
+
+	res.Service = v.application.ScheduleService.Status(terminal.Route, time.Now())
+
+	closures := v.application.ClosureService.GetActive(terminal.Route, time.Now())
+	if c := closures.Find(terminal.ID); c != nil {
+		c.Localize(locale)
+		info := c.ToClosureInfo()
+		res.Bus = busInfo
+		res.Closure = &info
+		return res, nil
+	}
+	closed := closures.ClosedIndex(terminals)
+
+	route := dto.TerminalSlice(terminals).ToRoute()
+	target := dto.TerminalSlice(terminals).Index(terminal.ID)
+
 	busLatestLocation = v.getBusLatestLocation()
 
 	for _, b := range busLatestLocation {
-		distance := common.Distance(b.Lat, b.Long, terminal.Lat, terminal.Long)
-		estimate := (distance / (b.GetBusSpeed() * 3.6)) * 60
-		v.shared.Logger.Infof("speed: %f, distance: %f, estimate: %f", b.Speed, distance, estimate)
+		if b.Route != terminal.Route {
+			continue
+		}
+
+		progress := v.busProgress(b, route)
+		eta := estimateArrival(b, progress, route, target, v.segmentTime(terminals), closed)
+		v.shared.Logger.Infof("speed: %f, distance: %f, estimate: %f", b.Speed, eta.Distance, eta.Estimate)
 		busInfo = append(busInfo, dto.BusInfo{
-			ID:       b.ID,
-			Number:   b.Number,
-			Plate:    b.Plate,
-			Status:   b.Status,
-			Route:    b.Route,
-			Estimate: int(estimate),
+			ID:           b.ID,
+			Number:       b.Number,
+			Plate:        b.Plate,
+			Status:       b.Status,
+			Route:        b.Route,
+			Estimate:     int(eta.Estimate),
+			NextTerminal: terminals[eta.Next].Name,
+			StopsAway:    eta.StopsAway,
 		})
 	}
 
@@ -303,8 +349,170 @@ func (v *viewService) BusInfo(id string) (dto.BusInfoResponse, error) {
 	return res, nil
 }
 
+/**
+ * Get every upcoming terminal of a bus in route order with arrival estimation
+ * Terminal the bus currently stopped at and closed terminal are not included
+ */
+func (v *viewService) UpcomingStop(id string) (dto.UpcomingStopResponse, error) {
+	var (
+		res       dto.UpcomingStopResponse
+		bus       dto.TrackLocationResponse
+		found     bool
+		terminals = []dto.Terminal{}
+		stops     = make([]dto.UpcomingStop, 0)
+		now       = time.Now()
+	)
+
+	for _, b := range v.getBusLatestLocation() {
+		if strconv.FormatUint(uint64(b.ID), 10) == id {
+			bus, found = b, true
+			break
+		}
+	}
+
+	if !found {
+		return res, errors.New("bus is not active")
+	}
+
+	err := v.application.TerminalService.GetAllByRoute(bus.Route, &terminals)
+	if err != nil {
+		v.shared.Logger.Errorf("error when finding all terminal by route, err: %s", err.Error())
+		return res, err
+	}
+
+	if len(terminals) == 0 {
+		return bus.ToUpcomingStopResponse(stops), nil
+	}
+
+	route := dto.TerminalSlice(terminals).ToRoute()
+	progress := v.busProgress(bus, route)
+	closed := v.application.ClosureService.GetActive(bus.Route, now).ClosedIndex(terminals)
+
+	for i, target := 0, route.Next(progress.Segment); i < len(terminals); i, target = i+1, route.Next(target) {
+		if closed[target] || (progress.AtTerminal && target == progress.LastVisited) {
+			continue
+		}
+
+		eta := estimateArrival(bus, progress, route, target, v.segmentTime(terminals), closed)
+		stops = append(stops, dto.UpcomingStop{
+			ID:        terminals[target].ID,
+			Name:      terminals[target].Name,
+			StopsAway: eta.StopsAway,
+			Distance:  eta.Distance,
+			Estimate:  int(eta.Estimate),
+			ArrivalAt: now.Add(time.Duration(eta.Estimate * float64(time.Minute))),
+		})
+	}
+
+	res = bus.ToUpcomingStopResponse(stops)
+
+	return res, nil
+}
+
+/**
+ * Progress of the bus tracked by the ingest path, read only
+ * Bus without fresh progress projected to the route without being cached,
+ * so an old location never keeps a stopped bus alive
+ */
+func (v *viewService) busProgress(bus dto.TrackLocationResponse, route common.Route) dto.BusProgress {
+	progress, ok := v.application.ProgressService.Get(bus.ID)
+	if ok && progress.Route == bus.Route && progress.Segment < len(route.Points) && time.Since(progress.UpdatedAt) < dto.PROGRESSEXPIRY {
+		return progress
+	}
+
+	projection := route.Project(bus.Lat, bus.Long)
+	return dto.BusProgress{
+		BusID:       bus.ID,
+		Route:       bus.Route,
+		LastVisited: projection.Segment,
+		Segment:     projection.Segment,
+		Offset:      projection.Offset,
+	}
+}
+
+/**
+ * Historical travel time lookup for segment of the ordered terminal
+ */
+func (v *viewService) segmentTime(terminals []dto.Terminal) segmentTime {
+	return func(segment int, at time.Time) (float64, bool) {
+		from := terminals[segment]
+		to := terminals[(segment+1)%len(terminals)]
+		return v.application.TravelTimeService.SegmentTime(from.ID, to.ID, at)
+	}
+}
+
+/**
+ * Store crowd status change with the latest bus location and nearest terminal
+ */
+func (v *viewService) recordCrowdStatus(bus dto.Bus, previous dto.BusStatus) {
+	var (
+		location  = dto.BusLocation{}
+		terminals = []dto.Terminal{}
+	)
+
+	change := &dto.CrowdStatusChange{
+		BusID:          bus.ID,
+		Route:          bus.Route,
+		Status:         bus.Status,
+		PreviousStatus: previous,
+		Timestamp:      time.Now(),
+	}
+
+	err := v.application.BusService.FindBusLatestLocation(bus.ID, &location)
+	if err == nil {
+		change.Lat = location.Lat
+		change.Long = location.Long
+
+		err = v.application.TerminalService.GetAllByRoute(bus.Route, &terminals)
+		if err != nil {
+			v.shared.Logger.Errorf("error when finding all terminal by route, err: %s", err.Error())
+		}
+
+		nearest := math.MaxFloat64
+		for i, t := range terminals {
+			if d := common.Distance(location.Lat, location.Long, t.Lat, t.Long); d < nearest {
+				nearest = d
+				change.TerminalID = &terminals[i].ID
+			}
+		}
+	}
+
+	err = v.application.CrowdService.Create(change)
+	if err != nil {
+		v.shared.Logger.Errorf("error when inserting crowd status change, err: %s", err.Error())
+	}
+}
+
+/**
+ * Update bus progress and terminal geofence on every received location
+ */
+func (v *viewService) processLocation(bus dto.Bus, location dto.BusLocation) {
+	var (
+		terminals = []dto.Terminal{}
+	)
+
+	err := v.application.TerminalService.GetAllByRoute(bus.Route, &terminals)
+	if err != nil {
+		v.shared.Logger.Errorf("error when finding all terminal by route, err: %s", err.Error())
+		return
+	}
+
+	v.application.ProgressService.Observe(bus.ID, bus.Route, location.Lat, location.Long, dto.TerminalSlice(terminals).ToRoute())
+
+	events := v.application.GeofenceService.Process(bus.ID, bus.Route, location.Lat, location.Long, location.Timestamp, terminals)
+	if len(events) == 0 {
+		return
+	}
+
+	err = v.application.GeofenceService.Create(&events)
+	if err != nil {
+		v.shared.Logger.Errorf("error when inserting terminal event, err: %s", err.Error())
+	}
+}
+
 /**
  * Get latest location for each bus
+ * Virtual bus from simulator included when simulator is running
  */
 func (v *viewService) getBusLatestLocation() []dto.TrackLocationResponse {
 	var (
@@ -323,14 +531,7 @@ func (v *viewService) getBusLatestLocation() []dto.TrackLocationResponse {
 			continue
 		}
 
-		parsedData := dto.TrackLocationResponse{
-			ID:       d.ID,
-			Number:   d.Number,
-			Status:   d.Status,
-			Route:    d.Route,
-			Plate:    d.Plate,
-			IsActive: d.IsActive,
-		}
+		parsedData := d.ToTrackLocationResponse()
 		location := dto.BusLocation{}
 		err = v.application.BusService.FindBusLatestLocation(d.ID, &location)
 		if err != nil {
@@ -345,42 +546,82 @@ func (v *viewService) getBusLatestLocation() []dto.TrackLocationResponse {
 		response = append(response, parsedData)
 	}
 
+	response = append(response, v.application.SimulatorService.GetFleet()...)
+
+	v.blendCrowd(response)
+
 	return response
 }
 
 /**
- * Store bus location using sync.map
+ * Blend driver crowd status of every bus with rider report
+ */
+func (v *viewService) blendCrowd(response []dto.TrackLocationResponse) {
+	now := time.Now()
+	for i := range response {
+		response[i].BlendCrowd(v.application.ReportService.Signal(response[i].ID, now))
+	}
+}
+
+/**
+ * Store bus location in the fleet state of requested sandbox
+ * Sandbox token already checked when the connection opened
  */
 func (v *viewService) storeBusLocationExperimental(data dto.BusLocationMessage, query dto.BusLocationQuery) (dto.BusLocationMessage, error) {
-	dto.ExperimentalBusLocation.Store(query.ExperminetalID, data)
+	if query.ExperminetalID == "" {
+		return data, errors.New("experimentalId is required")
+	}
+	v.application.SandboxService.StoreLocation(query.Sandbox, query.ExperminetalID, data)
 	return data, nil
 }
 
 /**
- * Get all latest bus location from sync.map
+ * Get all latest bus location in requested sandbox
+ * Bus metadata taken from linked bus when available
  */
-func (v *viewService) streamBusLocationExperimental() []dto.TrackLocationResponse {
-	var res = make([]dto.TrackLocationResponse, 0)
-	dto.ExperimentalBusLocation.Range(func(key, value interface{}) bool {
-		location := value.(dto.BusLocationMessage)
-		number, _ := strconv.Atoi(key.(string))
-		res = append(res, dto.TrackLocationResponse{
-			Number:  number,
-			Plate:   "P 4 L",
-			Long:    location.Long,
-			Lat:     location.Lat,
-			Speed:   location.Speed,
-			Heading: location.Heading,
-		})
-		return true
+func (v *viewService) streamBusLocationExperimental(query dto.BusLocationQuery) []dto.TrackLocationResponse {
+	var (
+		res        = make([]dto.TrackLocationResponse, 0)
+		sandboxBus = []dto.SandboxBus{}
+		linked     = make(map[string]dto.Bus)
+	)
+
+	err := v.application.SandboxService.FindBusBySandboxName(query.Sandbox, &sandboxBus)
+	if err != nil {
+		v.shared.Logger.Errorf("error when finding sandbox bus, err: %s", err.Error())
+	}
+
+	for _, b := range sandboxBus {
+		linked[b.ExperimentalID] = b.Bus
+	}
+
+	for id, location := range v.application.SandboxService.GetFleet(query.Sandbox) {
+		parsedData := dto.TrackLocationResponse{
+			Plate:    id,
+			IsActive: true,
+		}
+		if bus, ok := linked[id]; ok {
+			parsedData = bus.ToTrackLocationResponse()
+		}
+		parsedData.Lat = location.Lat
+		parsedData.Long = location.Long
+		parsedData.Speed = location.Speed
+		parsedData.Heading = location.Heading
+
+		res = append(res, parsedData)
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Plate < res[j].Plate
 	})
+
 	return res
 }
 
 /**
  * Store bus latest location received from web socket
- * * if the request is using experimental tracking, store it in local map
- * Bus location store asynchronously
+ * * if the request is using experimental tracking, store it in sandbox fleet state
+ * Bus location store asynchronously, progress and geofence updated in order of arrival
  */
  func (v *viewService) TrackBusLocationFirebase(query dto.BusLocationQuery, c *websocket.Conn, client *firestore.Client, firebaseCtx context.Context) (dto.BusLocationMessage, error) {
 	var (
@@ -397,7 +638,7 @@ func (v *viewService) streamBusLocationExperimental() []dto.TrackLocationRespons
 		return v.storeBusLocationExperimental(data, query)
 	}
 
-	_, strID, err := common.ExtractTokenData(query.Token, v.shared.Env)
+	username, strID, err := common.ExtractTokenData(query.Token, v.shared.Env)
 	if err != nil {
 		v.shared.Logger.Errorf("error when parsing jwt, err: %s", err.Error())
 		return data, err
@@ -418,9 +659,143 @@ func (v *viewService) streamBusLocationExperimental() []dto.TrackLocationRespons
 		v.shared.Logger.Infof("insert bus location firebase, data: %s", location)
 	}()
 
+	bus := dto.Bus{}
+	if err := v.application.BusService.FindByUsername(username, &bus); err != nil {
+		v.shared.Logger.Errorf("error when finding bus by username, err: %s", err.Error())
+		return data, nil
+	}
+
+	v.processLocation(bus, dto.BusLocation{
+		BusID:     bus.ID,
+		Lat:       data.Lat,
+		Long:      data.Long,
+		Timestamp: now,
+		Speed:     data.Speed,
+		Heading:   data.Heading,
+	})
+
 	return data, nil
 }
 
+/**
+ * Send the latest bus location from firebase to websocket client
+ * Listen to recent bus_locations documents and push update on every snapshot
+ * Listener stopped when the client closes the connection
+ */
+func (v *viewService) StreamBusLocationFirebase(query dto.BusLocationQuery, c *websocket.Conn, client *firestore.Client, firebaseCtx context.Context) error {
+	var (
+		latest = make(map[int64]dto.FirebaseBusLocation)
+	)
+
+	listenCtx, cancel := context.WithCancel(firebaseCtx)
+	defer cancel()
+
+	go func() {
+		defer cancel()
+		for {
+			if _, _, err := c.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	iter := v.application.BusService.ListenBusLocationFirebase(time.Now().Add(-dto.FIREBASESTREAMWINDOW), client, listenCtx)
+	defer iter.Stop()
+
+	for {
+		snapshot, err := iter.Next()
+		if err != nil {
+			if listenCtx.Err() != nil {
+				return nil
+			}
+			v.shared.Logger.Errorf("error when listening to firebase, err: %s", err.Error())
+			return err
+		}
+
+		for _, change := range snapshot.Changes {
+			if change.Kind == firestore.DocumentRemoved {
+				continue
+			}
+
+			location := dto.FirebaseBusLocation{}
+			if err := change.Doc.DataTo(&location); err != nil {
+				v.shared.Logger.Errorf("error when parsing firebase document, err: %s", err.Error())
+				continue
+			}
+
+			if current, ok := latest[location.BusID]; ok && current.Timestamp.After(location.Timestamp) {
+				continue
+			}
+			latest[location.BusID] = location
+		}
+
+		if err := c.WriteJSON(v.StreamMessage(query, v.getBusLatestLocationFirebase(latest))); err != nil {
+			v.shared.Logger.Errorf("error when sending websocket message, err: %s", err.Error())
+			return err
+		}
+	}
+}
+
+/**
+ * Build stream message for rider
+ * * if schedule is requested, bus location sent along with service status of every route
+ * so client can tell an empty map from a route not in service
+ */
+func (v *viewService) StreamMessage(query dto.BusLocationQuery, bus []dto.TrackLocationResponse) interface{} {
+	if query.Schedule != "true" {
+		return bus
+	}
+
+	var (
+		now     = time.Now()
+		service = make([]dto.RouteServiceStatus, 0)
+	)
+
+	for _, route := range v.application.RouteService.GetActive() {
+		service = append(service, v.application.ScheduleService.Status(route, now))
+	}
+
+	return dto.StreamBusLocationResponse{
+		Bus:     bus,
+		Service: service,
+	}
+}
+
+/**
+ * Merge latest firebase location with bus data
+ */
+func (v *viewService) getBusLatestLocationFirebase(latest map[int64]dto.FirebaseBusLocation) []dto.TrackLocationResponse {
+	var (
+		bus      = []dto.Bus{}
+		response = make([]dto.TrackLocationResponse, 0)
+	)
+
+	err := v.application.BusService.FindAllBus(&bus)
+	if err != nil {
+		v.shared.Logger.Errorf("error when finding all bus, err: %s", err.Error())
+		return response
+	}
+
+	for _, d := range bus {
+		location, ok := latest[int64(d.ID)]
+		if !d.IsActive || !ok {
+			continue
+		}
+
+		parsedData := d.ToTrackLocationResponse()
+		parsedData.Lat = location.Lat
+		parsedData.Long = location.Long
+		parsedData.Speed = location.Speed
+		parsedData.Heading = location.Heading
+
+		response = append(response, parsedData)
+	}
+
+	v.blendCrowd(response)
+
+	return response
+}
+
 func NewViewService(application application.Holder, shared shared.Holder) ViewService {
 	return &viewService{
 		application: application,
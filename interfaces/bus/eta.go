@@ -0,0 +1,110 @@
+package bus
+
+import (
+	"time"
+	"tracking-server/shared/common"
+	"tracking-server/shared/dto"
+)
+
+type (
+	arrival struct {
+		Distance  float64
+		Estimate  float64
+		StopsAway int
+		Next      int
+	}
+
+	// segmentTime historical travel time in second of a route segment
+	segmentTime func(segment int, at time.Time) (float64, bool)
+)
+
+/**
+ * Estimate arrival in minute along the route
+ * Start from the bus progress, a terminal already passed is reached on the next lap
+ */
+func estimateArrival(bus dto.TrackLocationResponse, progress dto.BusProgress, route common.Route, target int, travelTime segmentTime, closed map[int]bool) arrival {
+	res := arrival{
+		Next: nextServed(route, progress.Segment, closed),
+	}
+
+	if progress.AtTerminal && progress.LastVisited == target {
+		return res
+	}
+
+	projection := common.RouteProjection{
+		Segment: progress.Segment,
+		Offset:  progress.Offset,
+	}
+
+	res.Distance = route.DistanceTo(projection, target)
+	res.Estimate = travelMinute(bus, progress, route, target, travelTime)
+	res.StopsAway = route.StopsBetween(progress.Segment, target)
+	if res.StopsAway == 0 {
+		res.StopsAway = len(route.Points)
+	}
+	res.StopsAway -= closedBetween(route, progress.Segment, res.StopsAway, closed)
+
+	return res
+}
+
+/**
+ * Next terminal the bus stop at, closed terminal passed without stopping
+ */
+func nextServed(route common.Route, segment int, closed map[int]bool) int {
+	next := route.Next(segment)
+	for n := 0; n < len(route.Points) && closed[next]; n++ {
+		next = route.Next(next)
+	}
+	return next
+}
+
+/**
+ * Number of closed terminal within the given stop count after the segment start
+ */
+func closedBetween(route common.Route, segment int, stops int, closed map[int]bool) int {
+	count := 0
+	for n, i := 0, route.Next(segment); n < stops; n, i = n+1, route.Next(i) {
+		if closed[i] {
+			count++
+		}
+	}
+	return count
+}
+
+/**
+ * Sum travel time of every segment until the target
+ * Each segment use historical travel time of the hour the bus is expected there,
+ * fallback to live speed when the history is thin
+ */
+func travelMinute(bus dto.TrackLocationResponse, progress dto.BusProgress, route common.Route, target int, travelTime segmentTime) float64 {
+	var (
+		seconds   float64
+		now       = time.Now()
+		segment   = progress.Segment
+		remaining = 1.0
+	)
+
+	if target < 0 || target >= len(route.Points) || segment < 0 || segment >= len(route.Points) {
+		return 0
+	}
+
+	if route.Segments[segment] > 0 {
+		remaining = 1 - progress.Offset/route.Segments[segment]
+	}
+
+	for n := 0; n < len(route.Points); n++ {
+		if t, ok := travelTime(segment, now.Add(time.Duration(seconds)*time.Second)); ok {
+			seconds += t * remaining
+		} else {
+			seconds += route.Segments[segment] * remaining * 1000 / bus.GetBusSpeed()
+		}
+
+		segment = route.Next(segment)
+		remaining = 1
+		if segment == target {
+			break
+		}
+	}
+
+	return seconds / 60
+}
@@ -0,0 +1,190 @@
+package closure
+
+import (
+	"errors"
+	"time"
+	"tracking-server/application"
+	"tracking-server/shared"
+	"tracking-server/shared/dto"
+)
+
+type (
+	ViewService interface {
+		GetAllClosure(query dto.GetAllClosureQuery) (dto.GetAllClosureResponse, error)
+		GetClosure(id string) (dto.ClosureResponse, error)
+		CreateClosure(data dto.CreateClosureDto) (dto.ClosureResponse, error)
+		EditClosure(data dto.EditClosureDto, id string) (dto.ClosureResponse, error)
+		DeleteClosure(id string) error
+	}
+	viewService struct {
+		application application.Holder
+		shared      shared.Holder
+	}
+)
+
+/**
+ * Get closure ordered by start time
+ * * if active is set, closure already ended not included
+ */
+func (v *viewService) GetAllClosure(query dto.GetAllClosureQuery) (dto.GetAllClosureResponse, error) {
+	var (
+		res      = dto.GetAllClosureResponse{Closures: make([]dto.ClosureResponse, 0)}
+		closures = []dto.Closure{}
+		now      = time.Now()
+	)
+
+	err := v.application.ClosureService.FindAll(query, &closures)
+	if err != nil {
+		v.shared.Logger.Errorf("error when finding all closure, err: %s", err.Error())
+		return res, err
+	}
+
+	for _, c := range closures {
+		res.Closures = append(res.Closures, c.ToClosureResponse(now))
+	}
+
+	return res, nil
+}
+
+func (v *viewService) GetClosure(id string) (dto.ClosureResponse, error) {
+	closure := dto.Closure{}
+
+	err := v.application.ClosureService.FindById(id, &closure)
+	if err != nil {
+		v.shared.Logger.Errorf("error when finding closure by id, err: %s", err.Error())
+		return dto.ClosureResponse{}, err
+	}
+
+	return closure.ToClosureResponse(time.Now()), nil
+}
+
+/**
+ * Close terminal of a route for a period, detour path replace the route shape meanwhile
+ * * every closed terminal must be on the closure route
+ */
+func (v *viewService) CreateClosure(data dto.CreateClosureDto) (dto.ClosureResponse, error) {
+	closure := data.ToClosure()
+
+	terminals, err := v.findTerminals(data.Route, data.TerminalIDs)
+	if err != nil {
+		return dto.ClosureResponse{}, err
+	}
+	closure.Terminals = terminals
+
+	if data.Detour != nil {
+		points, err := data.Detour.ToPoints()
+		if err != nil {
+			return dto.ClosureResponse{}, err
+		}
+		closure.SetDetour(points)
+	}
+
+	if closure.Empty() {
+		return dto.ClosureResponse{}, errors.New("closure must close a terminal or carry a detour")
+	}
+
+	err = v.application.ClosureService.Create(&closure)
+	if err != nil {
+		v.shared.Logger.Errorf("error when inserting closure to database, err: %s", err.Error())
+		return dto.ClosureResponse{}, err
+	}
+
+	return closure.ToClosureResponse(time.Now()), nil
+}
+
+/**
+ * Edit closure period, reason, closed terminal or detour path
+ * Route of a closure can not be changed
+ */
+func (v *viewService) EditClosure(data dto.EditClosureDto, id string) (dto.ClosureResponse, error) {
+	closure := dto.Closure{}
+
+	err := v.application.ClosureService.FindById(id, &closure)
+	if err != nil {
+		v.shared.Logger.Errorf("error when finding closure by id, err: %s", err.Error())
+		return dto.ClosureResponse{}, err
+	}
+
+	closure.FillClosureEdit(data)
+
+	if !closure.EndAt.After(closure.StartAt) {
+		return dto.ClosureResponse{}, errors.New("end time must be after start time")
+	}
+
+	if data.Detour != nil {
+		points, err := data.Detour.ToPoints()
+		if err != nil {
+			return dto.ClosureResponse{}, err
+		}
+		closure.SetDetour(points)
+	}
+
+	var terminals *[]dto.Terminal
+	if data.TerminalIDs != nil {
+		found, err := v.findTerminals(closure.Route, *data.TerminalIDs)
+		if err != nil {
+			return dto.ClosureResponse{}, err
+		}
+		terminals = &found
+		closure.Terminals = found
+	}
+
+	if closure.Empty() {
+		return dto.ClosureResponse{}, errors.New("closure must close a terminal or carry a detour")
+	}
+
+	err = v.application.ClosureService.Save(&closure, terminals)
+	if err != nil {
+		v.shared.Logger.Errorf("error when saving closure, err: %s", err.Error())
+		return dto.ClosureResponse{}, err
+	}
+
+	return closure.ToClosureResponse(time.Now()), nil
+}
+
+func (v *viewService) DeleteClosure(id string) error {
+	closure := dto.Closure{}
+
+	err := v.application.ClosureService.FindById(id, &closure)
+	if err != nil {
+		v.shared.Logger.Errorf("error when finding closure by id, err: %s", err.Error())
+		return err
+	}
+
+	err = v.application.ClosureService.Delete(&closure)
+	if err != nil {
+		v.shared.Logger.Errorf("error when deleting closure, err: %s", err.Error())
+		return err
+	}
+
+	return nil
+}
+
+func (v *viewService) findTerminals(route dto.Route, ids []uint) ([]dto.Terminal, error) {
+	terminals := []dto.Terminal{}
+
+	err := v.application.TerminalService.GetByIds(ids, &terminals)
+	if err != nil {
+		v.shared.Logger.Errorf("error when finding terminal by ids, err: %s", err.Error())
+		return terminals, err
+	}
+
+	if len(terminals) != len(ids) {
+		return terminals, errors.New("terminal not found")
+	}
+
+	for _, t := range terminals {
+		if t.Route != route {
+			return terminals, errors.New("terminal is not on the route")
+		}
+	}
+
+	return terminals, nil
+}
+
+func NewViewService(application application.Holder, shared shared.Holder) ViewService {
+	return &viewService{
+		application: application,
+		shared:      shared,
+	}
+}
@@ -0,0 +1,223 @@
+package crowd
+
+import (
+	"time"
+	"tracking-server/application"
+	"tracking-server/shared"
+	"tracking-server/shared/dto"
+)
+
+type (
+	ViewService interface {
+		GetStatistic(query dto.CrowdStatisticQuery) (dto.GetCrowdStatisticResponse, error)
+		PredictCrowd(id string, query dto.PredictCrowdQuery) (dto.PredictCrowdResponse, error)
+	}
+	viewService struct {
+		application application.Holder
+		shared      shared.Holder
+	}
+
+	// hourlyMinutes minute spent on each crowd status for every hour of the day in WIB
+	hourlyMinutes [24]dto.CrowdShare
+)
+
+/**
+ * Get crowding of every hour of the day per route
+ * Each status counted by the time it lasted until the next change
+ */
+func (v *viewService) GetStatistic(query dto.CrowdStatisticQuery) (dto.GetCrowdStatisticResponse, error) {
+	var (
+		res     = dto.GetCrowdStatisticResponse{Routes: make([]dto.RouteCrowdStatistic, 0)}
+		now     = time.Now()
+		changes = []dto.CrowdStatusChange{}
+	)
+
+	err := v.application.CrowdService.FindSince(query.Route, now.AddDate(0, 0, -historyDays(query.Days)), &changes)
+	if err != nil {
+		v.shared.Logger.Errorf("error when finding crowd status change, err: %s", err.Error())
+		return res, err
+	}
+
+	routes := v.application.RouteService.GetActive()
+	minutes := hourlyShare(changes, routes, now)
+
+	for _, route := range routes {
+		if query.Route != "" && query.Route != route {
+			continue
+		}
+
+		statistic := dto.RouteCrowdStatistic{
+			Route: route,
+			Hours: make([]dto.HourlyCrowd, 0, 24),
+		}
+		for hour, share := range minutes[route] {
+			total, level := share.Normalize()
+			statistic.Hours = append(statistic.Hours, dto.HourlyCrowd{
+				Hour:    hour,
+				Minutes: total,
+				Level:   level,
+				Share:   share,
+			})
+		}
+
+		res.Routes = append(res.Routes, statistic)
+	}
+
+	return res, nil
+}
+
+/**
+ * Predict crowd level of bus passing a terminal in the time window
+ * Use status reported near the terminal in the same hour of day,
+ * fallback to the route statistic when the terminal history is thin
+ */
+func (v *viewService) PredictCrowd(id string, query dto.PredictCrowdQuery) (dto.PredictCrowdResponse, error) {
+	var (
+		res      dto.PredictCrowdResponse
+		terminal = dto.Terminal{}
+		changes  = []dto.CrowdStatusChange{}
+		now      = time.Now()
+		from     = now
+		window   = dto.CROWDPREDICTWINDOW
+		share    dto.CrowdShare
+	)
+
+	err := v.application.TerminalService.GetById(id, &terminal)
+	if err != nil {
+		v.shared.Logger.Errorf("error when finding terminal by id, err: %s", err.Error())
+		return res, err
+	}
+
+	if at, err := time.Parse(time.RFC3339, query.At); err == nil {
+		from = at
+	}
+	if query.Window > 0 {
+		window = query.Window
+	}
+	to := from.Add(time.Duration(window) * time.Minute)
+	hours := windowHours(from, to)
+	since := now.AddDate(0, 0, -historyDays(query.Days))
+
+	res.TerminalID = terminal.ID
+	res.Terminal = terminal.Name
+	res.Route = terminal.Route
+	res.From = from
+	res.To = to
+
+	err = v.application.CrowdService.FindByTerminal(terminal.ID, since, &changes)
+	if err != nil {
+		v.shared.Logger.Errorf("error when finding crowd status change, err: %s", err.Error())
+		return res, err
+	}
+
+	for _, c := range changes {
+		if hours[c.Timestamp.In(dto.WIB).Hour()] {
+			share.Add(c.Status, 1)
+			res.Samples++
+		}
+	}
+	res.Source = dto.TERMINALSOURCE
+
+	if res.Samples < dto.CROWDMINSAMPLE {
+		changes = []dto.CrowdStatusChange{}
+		err = v.application.CrowdService.FindSince(terminal.Route, since, &changes)
+		if err != nil {
+			v.shared.Logger.Errorf("error when finding crowd status change, err: %s", err.Error())
+			return res, err
+		}
+
+		share = dto.CrowdShare{}
+		res.Samples = 0
+		minutes := hourlyShare(changes, []dto.Route{terminal.Route}, now)[terminal.Route]
+		for hour := range hours {
+			share.Add(dto.EMPTY, minutes[hour].Empty)
+			share.Add(dto.MODERATE, minutes[hour].Moderate)
+			share.Add(dto.FULL, minutes[hour].Full)
+		}
+		for _, c := range changes {
+			if hours[c.Timestamp.In(dto.WIB).Hour()] {
+				res.Samples++
+			}
+		}
+		res.Source = dto.ROUTESOURCE
+	}
+
+	total, level := share.Normalize()
+	if total == 0 {
+		res.Source = ""
+		return res, nil
+	}
+
+	res.Share = share
+	res.Level = level
+	res.Status = dto.LevelToStatus(level)
+
+	return res, nil
+}
+
+/**
+ * Spread every status over the hour of day it lasted
+ * Status last until the next change of the same bus, at most CROWDMAXINTERVAL
+ * Change must be ordered by bus then timestamp
+ */
+func hourlyShare(changes []dto.CrowdStatusChange, routes []dto.Route, now time.Time) map[dto.Route]*hourlyMinutes {
+	res := make(map[dto.Route]*hourlyMinutes)
+
+	for i, c := range changes {
+		end := c.Timestamp.Add(dto.CROWDMAXINTERVAL)
+		if i+1 < len(changes) && changes[i+1].BusID == c.BusID && changes[i+1].Timestamp.Before(end) {
+			end = changes[i+1].Timestamp
+		}
+		if now.Before(end) {
+			end = now
+		}
+
+		minutes, ok := res[c.Route]
+		if !ok {
+			minutes = &hourlyMinutes{}
+			res[c.Route] = minutes
+		}
+
+		for start := c.Timestamp; start.Before(end); {
+			boundary := start.Truncate(time.Hour).Add(time.Hour)
+			if end.Before(boundary) {
+				boundary = end
+			}
+			minutes[start.In(dto.WIB).Hour()].Add(c.Status, boundary.Sub(start).Minutes())
+			start = boundary
+		}
+	}
+
+	for _, route := range routes {
+		if _, ok := res[route]; !ok {
+			res[route] = &hourlyMinutes{}
+		}
+	}
+
+	return res
+}
+
+/**
+ * Hour of day in WIB touched by the time window
+ */
+func windowHours(from time.Time, to time.Time) map[int]bool {
+	hours := make(map[int]bool)
+	for t := from.Truncate(time.Hour); t.Before(to); t = t.Add(time.Hour) {
+		hours[t.In(dto.WIB).Hour()] = true
+	}
+	return hours
+}
+
+func historyDays(days int) int {
+	if days <= 0 {
+		return dto.CROWDHISTORYDAYS
+	}
+	return days
+}
+
+func NewViewService(application application.Holder, shared shared.Holder) ViewService {
+	return &viewService{
+		application: application,
+		shared:      shared,
+	}
+}
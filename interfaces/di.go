@@ -2,9 +2,22 @@ package interfaces
 
 import (
 	"tracking-server/interfaces/bus"
+	"tracking-server/interfaces/closure"
+	"tracking-server/interfaces/crowd"
+	"tracking-server/interfaces/geofence"
+	"tracking-server/interfaces/headway"
 	"tracking-server/interfaces/healthcheck"
 	"tracking-server/interfaces/news"
+	"tracking-server/interfaces/place"
+	"tracking-server/interfaces/report"
+	"tracking-server/interfaces/route"
+	"tracking-server/interfaces/sandbox"
+	"tracking-server/interfaces/schedule"
+	"tracking-server/interfaces/search"
+	"tracking-server/interfaces/station"
+	"tracking-server/interfaces/subscription"
 	"tracking-server/interfaces/terminal"
+	"tracking-server/interfaces/trip"
 
 	"github.com/pkg/errors"
 	"go.uber.org/dig"
@@ -12,10 +25,23 @@ import (
 
 type Holder struct {
 	dig.In
-	HealthcheckViewService healthcheck.ViewService
-	BusViewService         bus.ViewService
-	NewsViewService        news.ViewService
-	TerminalViewsService   terminal.ViewService
+	HealthcheckViewService  healthcheck.ViewService
+	BusViewService          bus.ViewService
+	NewsViewService         news.ViewService
+	TerminalViewsService    terminal.ViewService
+	StationViewService      station.ViewService
+	RouteViewService        route.ViewService
+	ClosureViewService      closure.ViewService
+	PlaceViewService        place.ViewService
+	SearchViewService       search.ViewService
+	SandboxViewService      sandbox.ViewService
+	GeofenceViewService     geofence.ViewService
+	HeadwayViewService      headway.ViewService
+	SubscriptionViewService subscription.ViewService
+	TripViewService         trip.ViewService
+	ScheduleViewService     schedule.ViewService
+	CrowdViewService        crowd.ViewService
+	ReportViewService       report.ViewService
 }
 
 /**
@@ -34,9 +60,68 @@ func Register(container *dig.Container) error {
 		return errors.Wrap(err, "failed to provide news view service")
 	}
 
+	if err := container.Provide(route.NewViewService); err != nil {
+		return errors.Wrap(err, "failed to provide route view service")
+	}
+
+	if err := container.Provide(closure.NewViewService); err != nil {
+		return errors.Wrap(err, "failed to provide closure view service")
+	}
+
+	if err := container.Provide(place.NewViewService); err != nil {
+		return errors.Wrap(err, "failed to provide place view service")
+	}
+
+	if err := container.Provide(search.NewViewService); err != nil {
+		return errors.Wrap(err, "failed to provide search view service")
+	}
+
 	if err := container.Provide(terminal.NewViewService); err != nil {
 		return errors.Wrap(err, "failed to provide terminal view service")
 	}
 
+	if err := container.Provide(station.NewViewService); err != nil {
+		return errors.Wrap(err, "failed to provide station view service")
+	}
+
+	if err := container.Provide(sandbox.NewViewService); err != nil {
+		return errors.Wrap(err, "failed to provide sandbox view service")
+	}
+
+	if err := container.Provide(geofence.NewViewService); err != nil {
+		return errors.Wrap(err, "failed to provide geofence view service")
+	}
+
+	if err := container.Provide(headway.NewViewService); err != nil {
+		return errors.Wrap(err, "failed to provide headway view service")
+	}
+
+	if err := container.Provide(subscription.NewViewService); err != nil {
+		return errors.Wrap(err, "failed to provide subscription view service")
+	}
+
+	if err := container.Provide(trip.NewViewService); err != nil {
+		return errors.Wrap(err, "failed to provide trip view service")
+	}
+
+	if err := container.Provide(schedule.NewViewService); err != nil {
+		return errors.Wrap(err, "failed to provide schedule view service")
+	}
+
+	if err := container.Provide(crowd.NewViewService); err != nil {
+		return errors.Wrap(err, "failed to provide crowd view service")
+	}
+
+	if err := container.Provide(report.NewViewService); err != nil {
+		return errors.Wrap(err, "failed to provide report view service")
+	}
+
 	return nil
 }
+
+/**
+ * Start background worker for each module
+ */
+func Workers(holder Holder) {
+	go holder.SubscriptionViewService.Run()
+}
@@ -0,0 +1,44 @@
+package geofence
+
+import (
+	"tracking-server/application"
+	"tracking-server/shared"
+	"tracking-server/shared/dto"
+)
+
+type (
+	ViewService interface {
+		GetTerminalEvent(query dto.TerminalEventQuery) (dto.GetTerminalEventResponse, error)
+	}
+	viewService struct {
+		application application.Holder
+		shared      shared.Holder
+	}
+)
+
+/**
+ * Get terminal arrival and departure history, latest event first
+ */
+func (v *viewService) GetTerminalEvent(query dto.TerminalEventQuery) (dto.GetTerminalEventResponse, error) {
+	var (
+		response dto.GetTerminalEventResponse
+		events   = []dto.TerminalEvent{}
+	)
+
+	err := v.application.GeofenceService.FindAll(query, &events)
+	if err != nil {
+		v.shared.Logger.Errorf("error when finding terminal event, err: %s", err.Error())
+		return response, err
+	}
+
+	response.Events = events
+
+	return response, nil
+}
+
+func NewViewService(application application.Holder, shared shared.Holder) ViewService {
+	return &viewService{
+		application: application,
+		shared:      shared,
+	}
+}
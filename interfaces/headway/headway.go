@@ -0,0 +1,85 @@
+package headway
+
+import (
+	"tracking-server/application"
+	"tracking-server/shared"
+	"tracking-server/shared/dto"
+
+	"github.com/gofiber/websocket/v2"
+)
+
+type (
+	ViewService interface {
+		GetHeadway(query dto.GetHeadwayQuery) dto.GetHeadwayResponse
+		StreamAlert(c *websocket.Conn) error
+	}
+	viewService struct {
+		application application.Holder
+		shared      shared.Holder
+	}
+)
+
+/**
+ * Get the latest headway of every route
+ * * if route is set, only headway of that route returned
+ */
+func (v *viewService) GetHeadway(query dto.GetHeadwayQuery) dto.GetHeadwayResponse {
+	response := dto.GetHeadwayResponse{
+		Routes: make([]dto.RouteHeadway, 0),
+	}
+
+	for _, r := range v.application.HeadwayService.GetHeadway() {
+		if query.Route == "" || r.Route == query.Route {
+			response.Routes = append(response.Routes, r)
+		}
+	}
+
+	return response
+}
+
+/**
+ * Send active alert then every new bunching and gap alert to the ops client
+ * Stop when the client disconnect
+ */
+func (v *viewService) StreamAlert(c *websocket.Conn) error {
+	alerts, unsubscribe := v.application.HeadwayService.Subscribe()
+	defer unsubscribe()
+
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		for {
+			if _, _, err := c.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	for _, alert := range v.application.HeadwayService.GetActiveAlert() {
+		if err := c.WriteJSON(alert); err != nil {
+			return err
+		}
+	}
+
+	for {
+		select {
+		case <-closed:
+			return nil
+		case alert, ok := <-alerts:
+			if !ok {
+				return nil
+			}
+			if err := c.WriteJSON(alert); err != nil {
+				v.shared.Logger.Errorf("error when sending headway alert, err: %s", err.Error())
+				return err
+			}
+		}
+	}
+}
+
+func NewViewService(application application.Holder, shared shared.Holder) ViewService {
+	return &viewService{
+		application: application,
+		shared:      shared,
+	}
+}
@@ -4,14 +4,15 @@ import (
 	"time"
 	"tracking-server/application"
 	"tracking-server/shared"
+	"tracking-server/shared/common"
 	"tracking-server/shared/dto"
 )
 
 type (
 	ViewService interface {
 		CreateNews(data dto.CreateNewsDto) (dto.CreateNewsResponse, error)
-		GetAllNews() (dto.GetAllNewsResponse, error)
-		GetNewsDetail(id string) (dto.News, error)
+		GetAllNews(locale common.Locale) (dto.GetAllNewsResponse, error)
+		GetNewsDetail(id string, locale common.Locale) (dto.News, error)
 		DeleteNews(id string) error
 		EditNews(data dto.EditNewsDto, id string) (dto.News, error)
 	}
@@ -30,6 +31,8 @@ func (v *viewService) CreateNews(data dto.CreateNewsDto) (dto.CreateNewsResponse
 	news = &dto.News{
 		Title:     data.Title,
 		Detail:    data.Detail,
+		TitleEn:   data.TitleEn,
+		DetailEn:  data.DetailEn,
 		CreatedAt: time.Now(),
 	}
 
@@ -39,12 +42,14 @@ func (v *viewService) CreateNews(data dto.CreateNewsDto) (dto.CreateNewsResponse
 		return response, err
 	}
 
+	v.application.SearchService.Invalidate()
+
 	response = news.ToCreateNewsResponse()
 
 	return response, nil
 }
 
-func (v *viewService) GetAllNews() (dto.GetAllNewsResponse, error) {
+func (v *viewService) GetAllNews(locale common.Locale) (dto.GetAllNewsResponse, error) {
 	var (
 		news     = &dto.NewsSlice{}
 		response dto.GetAllNewsResponse
@@ -56,12 +61,14 @@ func (v *viewService) GetAllNews() (dto.GetAllNewsResponse, error) {
 		return response, err
 	}
 
+	news.Localize(locale)
+
 	response = news.ToGetAllNewsResponse()
 
 	return response, nil
 }
 
-func (v *viewService) GetNewsDetail(id string) (dto.News, error) {
+func (v *viewService) GetNewsDetail(id string, locale common.Locale) (dto.News, error) {
 	var (
 		news = &dto.News{}
 	)
@@ -72,6 +79,8 @@ func (v *viewService) GetNewsDetail(id string) (dto.News, error) {
 		return *news, err
 	}
 
+	news.Localize(locale)
+
 	return *news, nil
 }
 
@@ -81,6 +90,8 @@ func (v *viewService) DeleteNews(id string) error {
 		v.shared.Logger.Errorf("error when deleting news, err: %s", err.Error())
 		return err
 	}
+
+	v.application.SearchService.Invalidate()
 	return nil
 }
 
@@ -103,6 +114,8 @@ func (v *viewService) EditNews(data dto.EditNewsDto, id string) (dto.News, error
 		return *news, err
 	}
 
+	v.application.SearchService.Invalidate()
+
 	return *news, nil
 }
 
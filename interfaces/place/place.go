@@ -0,0 +1,195 @@
+package place
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"tracking-server/application"
+	"tracking-server/shared"
+	"tracking-server/shared/common"
+	"tracking-server/shared/dto"
+)
+
+type (
+	ViewService interface {
+		SearchPlace(query dto.SearchPlaceQuery, locale common.Locale) (dto.SearchPlaceResponse, error)
+		GetPlace(id string, locale common.Locale) (dto.PlaceResponse, error)
+	}
+	viewService struct {
+		application application.Holder
+		shared      shared.Holder
+	}
+)
+
+/**
+ * Search place by name or alias, best match first
+ * Each place come with terminal to alight at per route, and terminal to board
+ * from the rider when rider location is given
+ */
+func (v *viewService) SearchPlace(query dto.SearchPlaceQuery, locale common.Locale) (dto.SearchPlaceResponse, error) {
+	var (
+		res    = dto.SearchPlaceResponse{Places: make([]dto.PlaceResponse, 0)}
+		places = []dto.Place{}
+		q      = strings.ToLower(strings.TrimSpace(query.Q))
+		limit  = query.Limit
+	)
+
+	if limit == 0 {
+		limit = dto.DEFAULTPLACELIMIT
+	}
+
+	err := v.application.PlaceService.Search(q, &places)
+	if err != nil {
+		v.shared.Logger.Errorf("error when searching place, err: %s", err.Error())
+		return res, err
+	}
+
+	sort.SliceStable(places, func(i, j int) bool {
+		return matchRank(places[i], q) < matchRank(places[j], q)
+	})
+	if len(places) > limit {
+		places = places[:limit]
+	}
+
+	stops, err := v.routeTerminals(locale)
+	if err != nil {
+		return res, err
+	}
+
+	for _, p := range places {
+		p.Localize(locale)
+		res.Places = append(res.Places, p.ToPlaceResponse(placeRoutes(p, stops, query)))
+	}
+
+	return res, nil
+}
+
+func (v *viewService) GetPlace(id string, locale common.Locale) (dto.PlaceResponse, error) {
+	place := dto.Place{}
+
+	err := v.application.PlaceService.FindById(id, &place)
+	if err != nil {
+		v.shared.Logger.Errorf("error when finding place by id, err: %s", err.Error())
+		return dto.PlaceResponse{}, err
+	}
+
+	stops, err := v.routeTerminals(locale)
+	if err != nil {
+		return dto.PlaceResponse{}, err
+	}
+
+	place.Localize(locale)
+
+	return place.ToPlaceResponse(placeRoutes(place, stops, dto.SearchPlaceQuery{})), nil
+}
+
+func (v *viewService) routeTerminals(locale common.Locale) (map[dto.Route][]dto.Terminal, error) {
+	res := make(map[dto.Route][]dto.Terminal)
+
+	for _, route := range v.application.RouteService.GetActive() {
+		terminals := []dto.Terminal{}
+		err := v.application.TerminalService.GetAllByRoute(route, &terminals)
+		if err != nil {
+			v.shared.Logger.Errorf("error when finding all terminal by route, err: %s", err.Error())
+			return nil, err
+		}
+		dto.TerminalSlice(terminals).Localize(locale)
+		res[route] = terminals
+	}
+
+	return res, nil
+}
+
+/**
+ * Alight at the nearest terminal related to the place, fallback to the nearest
+ * terminal of the route within walking distance
+ */
+func placeRoutes(place dto.Place, stops map[dto.Route][]dto.Terminal, query dto.SearchPlaceQuery) []dto.PlaceRoute {
+	var (
+		res     = make([]dto.PlaceRoute, 0)
+		related = make(map[uint]bool, len(place.Terminals))
+	)
+
+	for _, t := range place.Terminals {
+		related[t.ID] = true
+	}
+
+	for route, terminals := range stops {
+		path := dto.TerminalSlice(terminals).ToRoute()
+
+		alight := path.NearestStop(place.Lat, place.Long, math.MaxFloat64, func(i int) bool {
+			return !related[terminals[i].ID]
+		})
+		if alight < 0 {
+			alight = path.NearestStop(place.Lat, place.Long, dto.TRIPMAXWALK, nil)
+		}
+		if alight < 0 {
+			continue
+		}
+
+		distance := common.Distance(place.Lat, place.Long, terminals[alight].Lat, terminals[alight].Long)
+		r := dto.PlaceRoute{
+			Route:     route,
+			Alighting: terminals[alight].ToPlaceTerminal(distance),
+		}
+
+		if query.Lat != 0 || query.Long != 0 {
+			board := path.NearestStop(query.Lat, query.Long, math.MaxFloat64, nil)
+			if board >= 0 {
+				d := common.Distance(query.Lat, query.Long, terminals[board].Lat, terminals[board].Long)
+				boarding := terminals[board].ToPlaceTerminal(d)
+				r.Boarding = &boarding
+			}
+		}
+
+		res = append(res, r)
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Alighting.Distance < res[j].Alighting.Distance
+	})
+
+	return res
+}
+
+/**
+ * Exact name or alias first, then prefix, then partial match
+ */
+func matchRank(place dto.Place, query string) int {
+	best := rankName(place.Name, query)
+	if place.NameEn != "" {
+		best = minRank(best, rankName(place.NameEn, query))
+	}
+	for _, a := range place.Aliases {
+		best = minRank(best, rankName(a.Alias, query))
+	}
+	return best
+}
+
+func minRank(a int, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func rankName(name string, query string) int {
+	name = strings.ToLower(name)
+	switch {
+	case name == query:
+		return 0
+	case strings.HasPrefix(name, query):
+		return 1
+	case strings.Contains(name, query):
+		return 2
+	default:
+		return 3
+	}
+}
+
+func NewViewService(application application.Holder, shared shared.Holder) ViewService {
+	return &viewService{
+		application: application,
+		shared:      shared,
+	}
+}
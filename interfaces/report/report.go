@@ -0,0 +1,126 @@
+package report
+
+import (
+	"errors"
+	"strconv"
+	"time"
+	"tracking-server/application"
+	"tracking-server/shared"
+	"tracking-server/shared/dto"
+)
+
+type (
+	ViewService interface {
+		CreateReport(data dto.CreateReportDto, ip string) (dto.CreateReportResponse, error)
+		GetReviewQueue(query dto.ReviewQueueQuery) (dto.GetReviewQueueResponse, error)
+		ReviewReport(data dto.ReviewReportDto, id string) (dto.RiderReport, error)
+	}
+	viewService struct {
+		application application.Holder
+		shared      shared.Holder
+	}
+)
+
+/**
+ * Submit rider report of a bus, limited per device and per client ip
+ * Crowd report counted right away in the bus crowd signal
+ */
+func (v *viewService) CreateReport(data dto.CreateReportDto, ip string) (dto.CreateReportResponse, error) {
+	var (
+		response dto.CreateReportResponse
+		now      = time.Now()
+	)
+
+	if !v.busExist(data.BusID) {
+		return response, errors.New("bus not found")
+	}
+
+	if !v.application.ReportService.Allow(data.DeviceID, ip, now) {
+		return response, errors.New("too many report, try again later")
+	}
+
+	report := data.ToRiderReport(now)
+	report.ClientIP = ip
+
+	err := v.application.ReportService.Create(&report)
+	if err != nil {
+		v.shared.Logger.Errorf("error when inserting rider report to database, err: %s", err.Error())
+		return response, err
+	}
+
+	v.application.ReportService.Observe(report)
+
+	response = report.ToCreateReportResponse()
+
+	return response, nil
+}
+
+/**
+ * Get report with free text message waiting for ops review
+ */
+func (v *viewService) GetReviewQueue(query dto.ReviewQueueQuery) (dto.GetReviewQueueResponse, error) {
+	var (
+		res     dto.GetReviewQueueResponse
+		reports = []dto.RiderReport{}
+	)
+
+	err := v.application.ReportService.FindReviewQueue(query, &reports)
+	if err != nil {
+		v.shared.Logger.Errorf("error when finding review queue, err: %s", err.Error())
+		return res, err
+	}
+
+	res.Reports = reports
+
+	return res, nil
+}
+
+func (v *viewService) ReviewReport(data dto.ReviewReportDto, id string) (dto.RiderReport, error) {
+	var (
+		report = dto.RiderReport{}
+		now    = time.Now()
+	)
+
+	err := v.application.ReportService.FindById(id, &report)
+	if err != nil {
+		v.shared.Logger.Errorf("error when finding rider report, err: %s", err.Error())
+		return report, err
+	}
+
+	report.Review = data.Review
+	report.ReviewNote = data.Note
+	report.ReviewedAt = &now
+
+	err = v.application.ReportService.Save(&report)
+	if err != nil {
+		v.shared.Logger.Errorf("error when saving rider report, err: %s", err.Error())
+		return report, err
+	}
+
+	return report, nil
+}
+
+/**
+ * Check bus registered or running in the simulator
+ */
+func (v *viewService) busExist(id uint) bool {
+	bus := dto.Bus{}
+	if err := v.application.BusService.FindById(strconv.FormatUint(uint64(id), 10), &bus); err == nil {
+		return true
+	}
+
+	for _, b := range v.application.SimulatorService.GetFleet() {
+		if b.ID == id {
+			return true
+		}
+	}
+
+	return false
+}
+
+func NewViewService(application application.Holder, shared shared.Holder) ViewService {
+	return &viewService{
+		application: application,
+		shared:      shared,
+	}
+}
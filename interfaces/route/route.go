@@ -0,0 +1,216 @@
+package route
+
+import (
+	"errors"
+	"time"
+	"tracking-server/application"
+	"tracking-server/shared"
+	"tracking-server/shared/common"
+	"tracking-server/shared/dto"
+)
+
+type (
+	ViewService interface {
+		GetAllRoute(query dto.GetAllRouteQuery) (dto.GetAllRouteResponse, error)
+		GetRoute(code string) (dto.BusRoute, error)
+		CreateRoute(data dto.CreateRouteDto) (dto.BusRoute, error)
+		EditRoute(data dto.EditRouteDto, code string) (dto.BusRoute, error)
+		DeleteRoute(code string) error
+		GetRouteShape(code string, query dto.GetRouteShapeQuery) (dto.GetRouteShapeResponse, error)
+		ImportRouteShape(code string, data dto.ImportShapeDto) (dto.GetRouteShapeResponse, error)
+		DeleteRouteShape(code string) error
+	}
+	viewService struct {
+		application application.Holder
+		shared      shared.Holder
+	}
+)
+
+/**
+ * Get every route
+ * * if active is set, inactive route not included
+ */
+func (v *viewService) GetAllRoute(query dto.GetAllRouteQuery) (dto.GetAllRouteResponse, error) {
+	var (
+		res    = dto.GetAllRouteResponse{Routes: make([]dto.BusRoute, 0)}
+		routes = []dto.BusRoute{}
+	)
+
+	err := v.application.RouteService.FindAll(&routes)
+	if err != nil {
+		v.shared.Logger.Errorf("error when finding all route, err: %s", err.Error())
+		return res, err
+	}
+
+	for _, r := range routes {
+		if query.Active && !r.IsActive {
+			continue
+		}
+		res.Routes = append(res.Routes, r)
+	}
+
+	return res, nil
+}
+
+func (v *viewService) GetRoute(code string) (dto.BusRoute, error) {
+	route := dto.BusRoute{}
+
+	err := v.application.RouteService.FindByCode(code, &route)
+	if err != nil {
+		v.shared.Logger.Errorf("error when finding route by code, err: %s", err.Error())
+		return route, err
+	}
+
+	return route, nil
+}
+
+func (v *viewService) CreateRoute(data dto.CreateRouteDto) (dto.BusRoute, error) {
+	route := data.ToBusRoute()
+
+	if v.application.RouteService.Exist(route.Code) {
+		return route, errors.New("route already exist")
+	}
+
+	err := v.application.RouteService.Create(&route)
+	if err != nil {
+		v.shared.Logger.Errorf("error when inserting route to database, err: %s", err.Error())
+		return route, err
+	}
+
+	return route, nil
+}
+
+/**
+ * Edit route detail, route code can not be changed since bus and terminal refer to it
+ */
+func (v *viewService) EditRoute(data dto.EditRouteDto, code string) (dto.BusRoute, error) {
+	route, err := v.GetRoute(code)
+	if err != nil {
+		return route, err
+	}
+
+	route.FillRouteEdit(data)
+
+	err = v.application.RouteService.Save(&route)
+	if err != nil {
+		v.shared.Logger.Errorf("error when saving route, err: %s", err.Error())
+		return route, err
+	}
+
+	return route, nil
+}
+
+/**
+ * Delete route only when no bus or terminal still on it
+ */
+func (v *viewService) DeleteRoute(code string) error {
+	if _, err := v.GetRoute(code); err != nil {
+		return err
+	}
+
+	count, err := v.application.RouteService.CountReference(code)
+	if err != nil {
+		v.shared.Logger.Errorf("error when counting route reference, err: %s", err.Error())
+		return err
+	}
+
+	if count > 0 {
+		return errors.New("route is still used by bus or terminal")
+	}
+
+	err = v.application.RouteService.Delete(code)
+	if err != nil {
+		v.shared.Logger.Errorf("error when deleting route, err: %s", err.Error())
+		return err
+	}
+
+	return nil
+}
+
+/**
+ * Get route shape as encoded polyline and GeoJSON
+ * * format only return the requested form
+ * Detour in effect returned along as encoded polyline
+ */
+func (v *viewService) GetRouteShape(code string, query dto.GetRouteShapeQuery) (dto.GetRouteShapeResponse, error) {
+	route, err := v.GetRoute(code)
+	if err != nil {
+		return dto.GetRouteShapeResponse{}, err
+	}
+
+	shape, ok := v.application.ShapeService.Get(route.Code)
+	if !ok {
+		return dto.GetRouteShapeResponse{}, errors.New("route has no shape")
+	}
+
+	res := toRouteShapeResponse(route.Code, shape, query.Format)
+	res.Detours = v.application.ClosureService.GetActive(route.Code, time.Now()).ToDetourResponse()
+
+	return res, nil
+}
+
+/**
+ * Import route shape from encoded polyline or GeoJSON LineString
+ * Replace the existing shape and precompute distance along the new shape
+ */
+func (v *viewService) ImportRouteShape(code string, data dto.ImportShapeDto) (dto.GetRouteShapeResponse, error) {
+	route, err := v.GetRoute(code)
+	if err != nil {
+		return dto.GetRouteShapeResponse{}, err
+	}
+
+	points, err := data.ToPoints()
+	if err != nil {
+		return dto.GetRouteShapeResponse{}, err
+	}
+
+	shape := common.NewShape(points)
+
+	err = v.application.ShapeService.Replace(v.shared.DB, route.Code, dto.NewShapePoints(route.Code, shape))
+	if err != nil {
+		v.shared.Logger.Errorf("error when replacing route shape, err: %s", err.Error())
+		return dto.GetRouteShapeResponse{}, err
+	}
+
+	return toRouteShapeResponse(route.Code, shape, ""), nil
+}
+
+func (v *viewService) DeleteRouteShape(code string) error {
+	route, err := v.GetRoute(code)
+	if err != nil {
+		return err
+	}
+
+	err = v.application.ShapeService.Delete(route.Code)
+	if err != nil {
+		v.shared.Logger.Errorf("error when deleting route shape, err: %s", err.Error())
+		return err
+	}
+
+	return nil
+}
+
+func toRouteShapeResponse(route dto.Route, shape common.Shape, format dto.ShapeFormat) dto.GetRouteShapeResponse {
+	res := dto.GetRouteShapeResponse{
+		Route:  route,
+		Points: len(shape.Points),
+		Length: shape.Length,
+	}
+
+	if format == "" || format == dto.POLYLINE {
+		res.Polyline = common.EncodePolyline(shape.Points)
+	}
+
+	if format == "" || format == dto.GEOJSON {
+		res.GeoJSON = dto.ToGeoJSONFeature(route, shape)
+	}
+
+	return res
+}
+
+func NewViewService(application application.Holder, shared shared.Holder) ViewService {
+	return &viewService{
+		application: application,
+		shared:      shared,
+	}
+}
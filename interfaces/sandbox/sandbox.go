@@ -0,0 +1,196 @@
+package sandbox
+
+import (
+	"errors"
+	"strconv"
+	"time"
+	"tracking-server/application"
+	"tracking-server/shared"
+	"tracking-server/shared/common"
+	"tracking-server/shared/dto"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+type (
+	ViewService interface {
+		CreateSandbox(data dto.CreateSandboxDto) (dto.CreateSandboxResponse, error)
+		DeleteSandbox(name string, token string) error
+		GetSandboxBus(name string, token string) ([]dto.SandboxBusResponse, error)
+		LinkSandboxBus(data dto.LinkSandboxBusDto, name string, token string) (dto.SandboxBusResponse, error)
+		UnlinkSandboxBus(name string, experimentalID string, token string) error
+		Authorize(name string, token string) (dto.Sandbox, error)
+	}
+	viewService struct {
+		application application.Holder
+		shared      shared.Holder
+	}
+)
+
+/**
+ * Create new sandbox for experimental tracking
+ * Token only returned once, stored encrypted
+ */
+func (v *viewService) CreateSandbox(data dto.CreateSandboxDto) (dto.CreateSandboxResponse, error) {
+	var (
+		sandbox  *dto.Sandbox
+		response dto.CreateSandboxResponse
+	)
+
+	token, err := common.RandomToken(16)
+	if err != nil {
+		v.shared.Logger.Errorf("error when generating sandbox token, err: %s", err.Error())
+		return response, err
+	}
+
+	encryptedToken, err := bcrypt.GenerateFromPassword([]byte(token), bcrypt.DefaultCost)
+	if err != nil {
+		v.shared.Logger.Errorf("error when encrypting sandbox token, err: %s", err.Error())
+		return response, err
+	}
+
+	sandbox = &dto.Sandbox{
+		Name:      data.Name,
+		Token:     string(encryptedToken),
+		CreatedAt: time.Now(),
+	}
+
+	err = v.application.SandboxService.Create(sandbox)
+	if err != nil {
+		v.shared.Logger.Errorf("error when inserting sandbox to database, err: %s", err.Error())
+		return response, err
+	}
+
+	response = sandbox.ToCreateSandboxResponse(token)
+
+	return response, nil
+}
+
+/**
+ * Delete sandbox and drop its fleet state
+ */
+func (v *viewService) DeleteSandbox(name string, token string) error {
+	sandbox, err := v.Authorize(name, token)
+	if err != nil {
+		return err
+	}
+
+	err = v.application.SandboxService.Delete(&sandbox)
+	if err != nil {
+		v.shared.Logger.Errorf("error when deleting sandbox, err: %s", err.Error())
+		return err
+	}
+
+	return nil
+}
+
+/**
+ * Get all bus linked in a sandbox
+ */
+func (v *viewService) GetSandboxBus(name string, token string) ([]dto.SandboxBusResponse, error) {
+	var (
+		sandboxBus = []dto.SandboxBus{}
+		response   = make([]dto.SandboxBusResponse, 0)
+	)
+
+	sandbox, err := v.Authorize(name, token)
+	if err != nil {
+		return response, err
+	}
+
+	err = v.application.SandboxService.FindBusBySandbox(sandbox.ID, &sandboxBus)
+	if err != nil {
+		v.shared.Logger.Errorf("error when finding sandbox bus, err: %s", err.Error())
+		return response, err
+	}
+
+	for _, b := range sandboxBus {
+		response = append(response, b.ToSandboxBusResponse())
+	}
+
+	return response, nil
+}
+
+/**
+ * Link experimental bus to real bus metadata
+ */
+func (v *viewService) LinkSandboxBus(data dto.LinkSandboxBusDto, name string, token string) (dto.SandboxBusResponse, error) {
+	var (
+		response   dto.SandboxBusResponse
+		sandboxBus *dto.SandboxBus
+		bus        = dto.Bus{}
+	)
+
+	sandbox, err := v.Authorize(name, token)
+	if err != nil {
+		return response, err
+	}
+
+	err = v.application.BusService.FindById(strconv.FormatUint(uint64(data.BusID), 10), &bus)
+	if err != nil {
+		v.shared.Logger.Errorf("error when finding bus, err: %s", err.Error())
+		return response, err
+	}
+
+	sandboxBus = &dto.SandboxBus{
+		SandboxID:      sandbox.ID,
+		ExperimentalID: data.ExperimentalID,
+		BusID:          bus.ID,
+		Bus:            bus,
+	}
+
+	err = v.application.SandboxService.SaveBus(sandboxBus)
+	if err != nil {
+		v.shared.Logger.Errorf("error when linking sandbox bus, err: %s", err.Error())
+		return response, err
+	}
+
+	response = sandboxBus.ToSandboxBusResponse()
+
+	return response, nil
+}
+
+func (v *viewService) UnlinkSandboxBus(name string, experimentalID string, token string) error {
+	sandbox, err := v.Authorize(name, token)
+	if err != nil {
+		return err
+	}
+
+	err = v.application.SandboxService.DeleteBus(sandbox.ID, experimentalID)
+	if err != nil {
+		v.shared.Logger.Errorf("error when unlinking sandbox bus, err: %s", err.Error())
+		return err
+	}
+
+	return nil
+}
+
+/**
+ * Check sandbox token, used by rest api and experimental tracking stream
+ */
+func (v *viewService) Authorize(name string, token string) (dto.Sandbox, error) {
+	var (
+		sandbox = dto.Sandbox{}
+	)
+
+	err := v.application.SandboxService.FindByName(name, &sandbox)
+	if err != nil {
+		v.shared.Logger.Errorf("error when finding sandbox by name, err: %s", err.Error())
+		return sandbox, errors.New("sandbox not found")
+	}
+
+	err = bcrypt.CompareHashAndPassword([]byte(sandbox.Token), []byte(token))
+	if err != nil {
+		v.shared.Logger.Errorf("wrong sandbox token, err: %s", err.Error())
+		return sandbox, errors.New("invalid sandbox token")
+	}
+
+	return sandbox, nil
+}
+
+func NewViewService(application application.Holder, shared shared.Holder) ViewService {
+	return &viewService{
+		application: application,
+		shared:      shared,
+	}
+}
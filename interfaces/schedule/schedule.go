@@ -0,0 +1,134 @@
+package schedule
+
+import (
+	"errors"
+	"time"
+	"tracking-server/application"
+	"tracking-server/shared"
+	"tracking-server/shared/dto"
+)
+
+type (
+	ViewService interface {
+		GetSchedule() (dto.GetScheduleResponse, error)
+		CreateWindow(data dto.CreateOperatingWindowDto) (dto.OperatingWindow, error)
+		DeleteWindow(id string) error
+		CreateException(data dto.CreateServiceExceptionDto) (dto.ServiceException, error)
+		DeleteException(id string) error
+		GetServiceStatus() dto.GetServiceStatusResponse
+	}
+	viewService struct {
+		application application.Holder
+		shared      shared.Holder
+	}
+)
+
+/**
+ * Get every operating window and service exception
+ */
+func (v *viewService) GetSchedule() (dto.GetScheduleResponse, error) {
+	var (
+		res        dto.GetScheduleResponse
+		windows    = []dto.OperatingWindow{}
+		exceptions = []dto.ServiceException{}
+	)
+
+	err := v.application.ScheduleService.FindAllWindow(&windows)
+	if err != nil {
+		v.shared.Logger.Errorf("error when finding operating window, err: %s", err.Error())
+		return res, err
+	}
+
+	err = v.application.ScheduleService.FindAllException(&exceptions)
+	if err != nil {
+		v.shared.Logger.Errorf("error when finding service exception, err: %s", err.Error())
+		return res, err
+	}
+
+	res.Windows = windows
+	res.Exceptions = exceptions
+
+	return res, nil
+}
+
+/**
+ * Add operating window of a route, window can not pass midnight
+ */
+func (v *viewService) CreateWindow(data dto.CreateOperatingWindowDto) (dto.OperatingWindow, error) {
+	window := data.ToOperatingWindow()
+
+	if window.End <= window.Start {
+		return window, errors.New("window end must be after start")
+	}
+
+	err := v.application.ScheduleService.CreateWindow(&window)
+	if err != nil {
+		v.shared.Logger.Errorf("error when inserting operating window to database, err: %s", err.Error())
+		return window, err
+	}
+
+	return window, nil
+}
+
+func (v *viewService) DeleteWindow(id string) error {
+	err := v.application.ScheduleService.DeleteWindow(id)
+	if err != nil {
+		v.shared.Logger.Errorf("error when deleting operating window, err: %s", err.Error())
+		return err
+	}
+
+	return nil
+}
+
+/**
+ * Add holiday or semester break date range
+ */
+func (v *viewService) CreateException(data dto.CreateServiceExceptionDto) (dto.ServiceException, error) {
+	exception := data.ToServiceException()
+
+	if exception.EndDate < exception.StartDate {
+		return exception, errors.New("end date must not be before start date")
+	}
+
+	err := v.application.ScheduleService.CreateException(&exception)
+	if err != nil {
+		v.shared.Logger.Errorf("error when inserting service exception to database, err: %s", err.Error())
+		return exception, err
+	}
+
+	return exception, nil
+}
+
+func (v *viewService) DeleteException(id string) error {
+	err := v.application.ScheduleService.DeleteException(id)
+	if err != nil {
+		v.shared.Logger.Errorf("error when deleting service exception, err: %s", err.Error())
+		return err
+	}
+
+	return nil
+}
+
+/**
+ * Get whether each route is operating now and when it next starts
+ */
+func (v *viewService) GetServiceStatus() dto.GetServiceStatusResponse {
+	var (
+		routes = v.application.RouteService.GetActive()
+		res    = dto.GetServiceStatusResponse{Routes: make([]dto.RouteServiceStatus, 0, len(routes))}
+		now    = time.Now()
+	)
+
+	for _, route := range routes {
+		res.Routes = append(res.Routes, v.application.ScheduleService.Status(route, now))
+	}
+
+	return res
+}
+
+func NewViewService(application application.Holder, shared shared.Holder) ViewService {
+	return &viewService{
+		application: application,
+		shared:      shared,
+	}
+}
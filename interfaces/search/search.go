@@ -0,0 +1,38 @@
+package search
+
+import (
+	"tracking-server/application"
+	"tracking-server/shared"
+	"tracking-server/shared/common"
+	"tracking-server/shared/dto"
+)
+
+type (
+	ViewService interface {
+		Search(query dto.SearchQuery, locale common.Locale) (dto.SearchResponse, error)
+	}
+	viewService struct {
+		application application.Holder
+		shared      shared.Holder
+	}
+)
+
+/**
+ * Search terminal, place and news with prefix, alias and typo tolerant match
+ */
+func (v *viewService) Search(query dto.SearchQuery, locale common.Locale) (dto.SearchResponse, error) {
+	results, err := v.application.SearchService.Search(query, locale)
+	if err != nil {
+		v.shared.Logger.Errorf("error when searching, err: %s", err.Error())
+		return dto.SearchResponse{}, err
+	}
+
+	return dto.SearchResponse{Results: results}, nil
+}
+
+func NewViewService(application application.Holder, shared shared.Holder) ViewService {
+	return &viewService{
+		application: application,
+		shared:      shared,
+	}
+}
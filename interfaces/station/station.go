@@ -0,0 +1,279 @@
+package station
+
+import (
+	"errors"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+	"tracking-server/application"
+	"tracking-server/interfaces/bus"
+	"tracking-server/shared"
+	"tracking-server/shared/common"
+	"tracking-server/shared/dto"
+)
+
+type (
+	ViewService interface {
+		GetAllStation(query dto.GetAllStationQuery, locale common.Locale) (dto.GetAllStationResponse, error)
+		GetStation(id string, locale common.Locale) (dto.StationResponse, error)
+		GetStationArrival(id string, locale common.Locale) (dto.StationArrivalResponse, error)
+	}
+	viewService struct {
+		application application.Holder
+		shared      shared.Holder
+		bus         bus.ViewService
+	}
+
+	// ordered terminal and closure of a route to find the next served terminal
+	routeInfo struct {
+		terminals dto.TerminalSlice
+		index     map[uint]int
+		closures  dto.ClosureSlice
+		closed    map[int]bool
+	}
+)
+
+/**
+ * Get every station with the stop of each active route
+ * * if rider location is given, sorted by the nearest stop of the station
+ */
+func (v *viewService) GetAllStation(query dto.GetAllStationQuery, locale common.Locale) (dto.GetAllStationResponse, error) {
+	var (
+		res      = dto.GetAllStationResponse{Stations: make([]dto.StationResponse, 0)}
+		stations = []dto.Station{}
+		located  = query.Lat != 0 || query.Long != 0
+	)
+
+	err := v.application.StationService.FindAll(&stations)
+	if err != nil {
+		v.shared.Logger.Errorf("error when finding all station, err: %s", err.Error())
+		return res, err
+	}
+
+	routes, err := v.routeInfo(v.application.RouteService.GetActive(), locale)
+	if err != nil {
+		return res, err
+	}
+
+	for _, st := range stations {
+		st.Localize(locale)
+
+		stops := v.stationStops(st, routes)
+		if len(stops) == 0 {
+			continue
+		}
+
+		station := st.ToStationResponse(stops)
+		if located {
+			nearest := math.MaxFloat64
+			for _, s := range stops {
+				nearest = math.Min(nearest, common.Distance(query.Lat, query.Long, s.Lat, s.Long))
+			}
+			station.Distance = &nearest
+		}
+
+		res.Stations = append(res.Stations, station)
+	}
+
+	if located {
+		sort.SliceStable(res.Stations, func(i, j int) bool {
+			return *res.Stations[i].Distance < *res.Stations[j].Distance
+		})
+	}
+
+	if query.Limit > 0 && len(res.Stations) > query.Limit {
+		res.Stations = res.Stations[:query.Limit]
+	}
+
+	return res, nil
+}
+
+/**
+ * Get station with the stop of each active route and the bus arriving at each stop
+ */
+func (v *viewService) GetStation(id string, locale common.Locale) (dto.StationResponse, error) {
+	station := dto.Station{}
+
+	err := v.application.StationService.FindById(id, &station)
+	if err != nil {
+		v.shared.Logger.Errorf("error when finding station by id, err: %s", err.Error())
+		return dto.StationResponse{}, err
+	}
+
+	station.Localize(locale)
+
+	routes, err := v.routeInfo(v.stationRoutes(station), locale)
+	if err != nil {
+		return dto.StationResponse{}, err
+	}
+
+	stops := v.stationStops(station, routes)
+	for i := range stops {
+		info, err := v.bus.BusInfo(strconv.FormatUint(uint64(stops[i].TerminalID), 10), locale)
+		if err != nil {
+			return dto.StationResponse{}, err
+		}
+
+		stops[i].Bus = info.Bus
+		stops[i].Service = &info.Service
+	}
+
+	return station.ToStationResponse(stops), nil
+}
+
+/**
+ * Get the next bus of every route stopping at the station, sorted by estimate
+ * Service status and closure of each route returned so empty arrival can be told apart
+ */
+func (v *viewService) GetStationArrival(id string, locale common.Locale) (dto.StationArrivalResponse, error) {
+	var (
+		station = dto.Station{}
+		active  = make(map[dto.Route]bool)
+	)
+
+	err := v.application.StationService.FindById(id, &station)
+	if err != nil {
+		v.shared.Logger.Errorf("error when finding station by id, err: %s", err.Error())
+		return dto.StationArrivalResponse{}, err
+	}
+
+	station.Localize(locale)
+
+	res := dto.StationArrivalResponse{
+		ID:       station.ID,
+		Name:     station.Name,
+		Bus:      make([]dto.BusInfo, 0),
+		Service:  make([]dto.RouteServiceStatus, 0),
+		Closures: make([]dto.ClosureInfo, 0),
+	}
+
+	for _, r := range v.application.RouteService.GetActive() {
+		active[r] = true
+	}
+
+	for _, t := range station.Terminals {
+		if !active[t.Route] {
+			continue
+		}
+
+		info, err := v.bus.BusInfo(strconv.FormatUint(uint64(t.ID), 10), locale)
+		if err != nil {
+			return res, err
+		}
+
+		res.Bus = append(res.Bus, info.Bus...)
+		res.Service = append(res.Service, info.Service)
+		if info.Closure != nil {
+			res.Closures = append(res.Closures, *info.Closure)
+		}
+	}
+
+	if len(res.Service) == 0 {
+		return res, errors.New("station is not served by any active route")
+	}
+
+	sort.SliceStable(res.Bus, func(i, j int) bool {
+		return res.Bus[i].Estimate < res.Bus[j].Estimate
+	})
+
+	return res, nil
+}
+
+/**
+ * Ordered terminal and closure in effect of each route
+ */
+func (v *viewService) routeInfo(routes []dto.Route, locale common.Locale) (map[dto.Route]routeInfo, error) {
+	var (
+		res = make(map[dto.Route]routeInfo, len(routes))
+		now = time.Now()
+	)
+
+	for _, route := range routes {
+		terminals := []dto.Terminal{}
+
+		err := v.application.TerminalService.GetAllByRoute(route, &terminals)
+		if err != nil {
+			v.shared.Logger.Errorf("error when finding all terminal by route, err: %s", err.Error())
+			return res, err
+		}
+
+		dto.TerminalSlice(terminals).Localize(locale)
+
+		closures := v.application.ClosureService.GetActive(route, now)
+		closures.Localize(locale)
+
+		index := make(map[uint]int, len(terminals))
+		for i, t := range terminals {
+			index[t.ID] = i
+		}
+
+		res[route] = routeInfo{
+			terminals: terminals,
+			index:     index,
+			closures:  closures,
+			closed:    closures.ClosedIndex(terminals),
+		}
+	}
+
+	return res, nil
+}
+
+/**
+ * Stop of the station on the given route, next terminal skip closed terminal
+ */
+func (v *viewService) stationStops(station dto.Station, routes map[dto.Route]routeInfo) []dto.StationStop {
+	stops := make([]dto.StationStop, 0, len(station.Terminals))
+
+	for _, t := range station.Terminals {
+		r, ok := routes[t.Route]
+		if !ok {
+			continue
+		}
+
+		next := ""
+		if n := r.terminals.NextServed(r.index[t.ID], r.closed); n >= 0 {
+			next = r.terminals[n].Name
+		}
+
+		stop := t.ToStationStop(next)
+		if c := r.closures.Find(t.ID); c != nil {
+			info := c.ToClosureInfo()
+			stop.Closure = &info
+		}
+
+		stops = append(stops, stop)
+	}
+
+	return stops
+}
+
+/**
+ * Active route stopping at the station
+ */
+func (v *viewService) stationRoutes(station dto.Station) []dto.Route {
+	var (
+		res    = make([]dto.Route, 0)
+		served = make(map[dto.Route]bool)
+	)
+
+	for _, t := range station.Terminals {
+		served[t.Route] = true
+	}
+
+	for _, r := range v.application.RouteService.GetActive() {
+		if served[r] {
+			res = append(res, r)
+		}
+	}
+
+	return res
+}
+
+func NewViewService(application application.Holder, shared shared.Holder, bus bus.ViewService) ViewService {
+	return &viewService{
+		application: application,
+		shared:      shared,
+		bus:         bus,
+	}
+}
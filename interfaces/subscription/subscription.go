@@ -0,0 +1,286 @@
+package subscription
+
+import (
+	"errors"
+	"strconv"
+	"time"
+	"tracking-server/application"
+	"tracking-server/interfaces/bus"
+	"tracking-server/shared"
+	"tracking-server/shared/common"
+	"tracking-server/shared/dto"
+
+	"github.com/gofiber/websocket/v2"
+)
+
+type (
+	ViewService interface {
+		CreateSubscription(data dto.CreateSubscriptionDto) (dto.CreateSubscriptionResponse, error)
+		DeleteSubscription(key string) error
+		StreamNotification(key string, c *websocket.Conn) error
+		Run()
+		Evaluate()
+	}
+	viewService struct {
+		application application.Holder
+		shared      shared.Holder
+		bus         bus.ViewService
+	}
+
+	// arrival live estimate of every bus heading to a terminal
+	arrival struct {
+		terminal dto.Terminal
+		bus      []dto.BusInfo
+	}
+)
+
+/**
+ * Register one-shot arrival alert for a terminal
+ * Webhook channel only available when notification service is configured
+ */
+func (v *viewService) CreateSubscription(data dto.CreateSubscriptionDto) (dto.CreateSubscriptionResponse, error) {
+	var (
+		response dto.CreateSubscriptionResponse
+		terminal = dto.Terminal{}
+		now      = time.Now()
+		ttl      = dto.SUBSCRIPTIONDEFAULTTTL
+	)
+
+	if data.Channel == dto.WEBHOOK && v.shared.Env.NotificationWebhookURL == "" {
+		return response, errors.New("notification webhook is not configured")
+	}
+
+	err := v.application.TerminalService.GetById(strconv.FormatUint(uint64(data.TerminalID), 10), &terminal)
+	if err != nil {
+		v.shared.Logger.Errorf("error when finding terminal by id, err: %s", err.Error())
+		return response, err
+	}
+
+	if terminal.Route != data.Route {
+		return response, errors.New("terminal is not on the route")
+	}
+
+	key, err := common.RandomToken(16)
+	if err != nil {
+		v.shared.Logger.Errorf("error when generating subscription key, err: %s", err.Error())
+		return response, err
+	}
+
+	if data.ExpiresIn > 0 {
+		ttl = time.Duration(data.ExpiresIn) * time.Minute
+	}
+
+	subscription := &dto.ArrivalSubscription{
+		Key:        key,
+		TerminalID: terminal.ID,
+		Route:      data.Route,
+		Threshold:  data.Threshold,
+		Channel:    data.Channel,
+		Recipient:  data.Recipient,
+		ExpiresAt:  now.Add(ttl),
+		CreatedAt:  now,
+	}
+
+	err = v.application.SubscriptionService.Create(subscription)
+	if err != nil {
+		v.shared.Logger.Errorf("error when inserting subscription to database, err: %s", err.Error())
+		return response, err
+	}
+
+	response = subscription.ToCreateSubscriptionResponse()
+
+	return response, nil
+}
+
+func (v *viewService) DeleteSubscription(key string) error {
+	subscription := dto.ArrivalSubscription{}
+
+	err := v.application.SubscriptionService.FindByKey(key, &subscription)
+	if err != nil {
+		v.shared.Logger.Errorf("error when finding subscription by key, err: %s", err.Error())
+		return err
+	}
+
+	err = v.application.SubscriptionService.Delete(key)
+	if err != nil {
+		v.shared.Logger.Errorf("error when deleting subscription, err: %s", err.Error())
+		return err
+	}
+
+	return nil
+}
+
+/**
+ * Wait until the subscription is notified then send it to rider websocket
+ * Notification already sent before the rider connected is delivered right away
+ */
+func (v *viewService) StreamNotification(key string, c *websocket.Conn) error {
+	var (
+		subscription = dto.ArrivalSubscription{}
+		terminal     = dto.Terminal{}
+	)
+
+	notifications, unsubscribe := v.application.SubscriptionService.Subscribe(key)
+	defer unsubscribe()
+
+	err := v.application.SubscriptionService.FindByKey(key, &subscription)
+	if err != nil {
+		v.shared.Logger.Errorf("error when finding subscription by key, err: %s", err.Error())
+		return err
+	}
+
+	if subscription.NotifiedAt != nil {
+		err = v.application.TerminalService.GetById(strconv.FormatUint(uint64(subscription.TerminalID), 10), &terminal)
+		if err != nil {
+			v.shared.Logger.Errorf("error when finding terminal by id, err: %s", err.Error())
+			return err
+		}
+		return c.WriteJSON(subscription.ToArrivalNotification(terminal))
+	}
+
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		for {
+			if _, _, err := c.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	expired := time.NewTimer(time.Until(subscription.ExpiresAt))
+	defer expired.Stop()
+
+	select {
+	case <-closed:
+		return nil
+	case <-expired.C:
+		return errors.New("subscription expired")
+	case notification := <-notifications:
+		return c.WriteJSON(notification)
+	}
+}
+
+/**
+ * Evaluate subscription against live estimate periodically
+ * Evaluation disabled when interval is not set
+ */
+func (v *viewService) Run() {
+	interval := time.Duration(v.shared.Env.SubscriptionInterval) * time.Second
+	if interval <= 0 {
+		return
+	}
+
+	for {
+		v.Evaluate()
+		time.Sleep(interval)
+	}
+}
+
+/**
+ * Notify every pending subscription with a bus within its threshold
+ * Expired subscription removed before evaluation
+ */
+func (v *viewService) Evaluate() {
+	var (
+		now           = time.Now()
+		subscriptions = []dto.ArrivalSubscription{}
+		arrivals      = make(map[uint]arrival)
+	)
+
+	deleted, err := v.application.SubscriptionService.DeleteExpired(now)
+	if err != nil {
+		v.shared.Logger.Errorf("error when deleting expired subscription, err: %s", err.Error())
+	} else if deleted > 0 {
+		v.shared.Logger.Infof("delete expired subscription, total: %d", deleted)
+	}
+
+	err = v.application.SubscriptionService.FindPending(now, &subscriptions)
+	if err != nil {
+		v.shared.Logger.Errorf("error when finding pending subscription, err: %s", err.Error())
+		return
+	}
+
+	for i := range subscriptions {
+		subscription := &subscriptions[i]
+
+		a, ok := arrivals[subscription.TerminalID]
+		if !ok {
+			a, err = v.getArrival(subscription.TerminalID)
+			if err != nil {
+				continue
+			}
+			arrivals[subscription.TerminalID] = a
+		}
+
+		// bus info already sorted by estimate, the first match is the nearest bus
+		for _, b := range a.bus {
+			if b.Route == subscription.Route && b.Estimate <= subscription.Threshold {
+				v.notify(subscription, a.terminal, b, now)
+				break
+			}
+		}
+	}
+}
+
+func (v *viewService) getArrival(terminalID uint) (arrival, error) {
+	var (
+		res arrival
+		id  = strconv.FormatUint(uint64(terminalID), 10)
+	)
+
+	err := v.application.TerminalService.GetById(id, &res.terminal)
+	if err != nil {
+		v.shared.Logger.Errorf("error when finding terminal by id, err: %s", err.Error())
+		return res, err
+	}
+
+	info, err := v.bus.BusInfo(id, common.DEFAULTLOCALE)
+	if err != nil {
+		return res, err
+	}
+	res.bus = info.Bus
+
+	return res, nil
+}
+
+/**
+ * Send notification through the subscription channel then mark it as notified
+ * Failed webhook retried on the next evaluation
+ */
+func (v *viewService) notify(subscription *dto.ArrivalSubscription, terminal dto.Terminal, bus dto.BusInfo, at time.Time) {
+	subscription.NotifiedAt = &at
+	subscription.BusID = bus.ID
+	subscription.BusNumber = bus.Number
+	subscription.BusPlate = bus.Plate
+	subscription.Estimate = bus.Estimate
+
+	notification := subscription.ToArrivalNotification(terminal)
+
+	if subscription.Channel == dto.WEBHOOK {
+		if err := v.application.SubscriptionService.SendWebhook(notification); err != nil {
+			v.shared.Logger.Errorf("error when sending notification webhook, err: %s", err.Error())
+			return
+		}
+	}
+
+	err := v.application.SubscriptionService.MarkNotified(subscription)
+	if err != nil {
+		v.shared.Logger.Errorf("error when updating subscription, err: %s", err.Error())
+		return
+	}
+
+	if subscription.Channel == dto.WEBSOCKET {
+		v.application.SubscriptionService.Publish(notification)
+	}
+
+	v.shared.Logger.Infof("notify subscription, data: %v", notification)
+}
+
+func NewViewService(application application.Holder, shared shared.Holder, bus bus.ViewService) ViewService {
+	return &viewService{
+		application: application,
+		shared:      shared,
+		bus:         bus,
+	}
+}
@@ -1,7 +1,9 @@
 package terminal
 
 import (
+	"errors"
 	"sort"
+	"time"
 	"tracking-server/application"
 	"tracking-server/shared"
 	"tracking-server/shared/common"
@@ -10,9 +12,15 @@ import (
 
 type (
 	ViewService interface {
-		GetTerminalInfo(id string) (dto.GetTerminalInfoResponse, error)
-		GetAllTerminalSorted(data dto.GetAllTerminalDto) (dto.GetAllTerminalResponse, error)
-		GetTwoClosesTerminal(data dto.GetAllTerminalDto) (dto.GetAllTerminalResponse, error)
+		GetTerminalInfo(id string, locale common.Locale) (dto.GetTerminalInfoResponse, error)
+		GetNearestTerminal(query dto.NearestTerminalQuery, locale common.Locale) (dto.GetAllTerminalResponse, error)
+		GetAllTerminalSorted(data dto.GetAllTerminalDto, locale common.Locale) (dto.GetAllTerminalResponse, error)
+		GetTwoClosesTerminal(data dto.GetAllTerminalDto, locale common.Locale) (dto.GetAllTerminalResponse, error)
+		GetRouteTerminal(route dto.Route, locale common.Locale) (dto.GetRouteTerminalResponse, error)
+		CreateTerminal(data dto.CreateTerminalDto) (dto.TerminalResponse, error)
+		EditTerminal(data dto.EditTerminalDto, id string) (dto.TerminalResponse, error)
+		DeleteTerminal(id string) error
+		ReorderTerminal(data dto.ReorderTerminalDto) (dto.GetRouteTerminalResponse, error)
 	}
 	viewService struct {
 		application application.Holder
@@ -23,8 +31,9 @@ type (
 /**
  * Get terminal details
  * Details included related place and corresponding route
+ * Terminal closed at the moment marked along with the closure reason
  */
-func (v *viewService) GetTerminalInfo(id string) (dto.GetTerminalInfoResponse, error) {
+func (v *viewService) GetTerminalInfo(id string, locale common.Locale) (dto.GetTerminalInfoResponse, error) {
 	var (
 		response           dto.GetTerminalInfoResponse
 		terminal           = &dto.Terminal{}
@@ -43,73 +52,293 @@ func (v *viewService) GetTerminalInfo(id string) (dto.GetTerminalInfoResponse, e
 		return response, err
 	}
 
-	response = terminal.ToTerminalInfo(*allTerminalInRoute)
+	closures := v.application.ClosureService.GetActive(terminal.Route, time.Now())
+
+	terminal.Localize(locale)
+	dto.TerminalSlice(*allTerminalInRoute).Localize(locale)
+	closures.Localize(locale)
+
+	response = terminal.ToTerminalInfo(*allTerminalInRoute, closures)
 
 	return response, nil
 }
 
 /**
- * Get all terminal sorted by nearest to user
+ * Get terminal nearest to user, optionally limited to a route, radius and count
+ * Next terminal follow the terminal sequence of its own route, skipping closed terminal
+ * Closed terminal still listed along with the closure reason
  */
-func (v *viewService) GetAllTerminalSorted(data dto.GetAllTerminalDto) (dto.GetAllTerminalResponse, error) {
+func (v *viewService) GetNearestTerminal(query dto.NearestTerminalQuery, locale common.Locale) (dto.GetAllTerminalResponse, error) {
 	var (
-		res                dto.GetAllTerminalResponse
+		res                = dto.GetAllTerminalResponse{Terminals: make([]dto.TerminalListWithDistance, 0)}
 		terminals          = []dto.Terminal{}
 		terminalListSorted = make([]dto.TerminalListWithDistance, 0)
+		now                = time.Now()
+		err                error
 	)
 
-	err := v.application.TerminalService.GetAllTerminal(&terminals)
+	if query.Route != "" {
+		err = v.application.TerminalService.GetAllByRoute(query.Route, &terminals)
+	} else {
+		err = v.application.TerminalService.GetAllTerminal(&terminals)
+	}
 	if err != nil {
 		v.shared.Logger.Errorf("error when getting all terminal, err: %s", err.Error())
 		return res, err
 	}
 
-	for i := 0; i < len(terminals); i++ {
-		next := terminals[0]
-		if i != len(terminals)-1 {
-			next = terminals[i+1]
-		}
+	dto.TerminalSlice(terminals).Localize(locale)
+
+	routes := make(map[dto.Route][]dto.Terminal)
+	for _, t := range terminals {
+		routes[t.Route] = append(routes[t.Route], t)
+	}
 
-		terminalSorted := v.getTerminalDistance(data, terminals[i], next.Name)
+	for route, stops := range routes {
+		closures := v.application.ClosureService.GetActive(route, now)
+		closures.Localize(locale)
+		closed := closures.ClosedIndex(stops)
 
-		terminalListSorted = append(terminalListSorted, terminalSorted)
+		for i := range stops {
+			terminalSorted := v.getTerminalDistance(query, stops[i])
+			if query.Radius > 0 && terminalSorted.Distance > query.Radius {
+				continue
+			}
+
+			if n := dto.TerminalSlice(stops).NextServed(i, closed); n >= 0 {
+				terminalSorted.Next = stops[n].Name
+			}
+
+			if c := closures.Find(stops[i].ID); c != nil {
+				info := c.ToClosureInfo()
+				terminalSorted.Closure = &info
+			}
+
+			terminalListSorted = append(terminalListSorted, terminalSorted)
+		}
 	}
 
-	sort.Slice(terminalListSorted, func(i, j int) bool {
-		return terminalListSorted[i].Distance < terminalListSorted[j].Distance
+	sort.SliceStable(terminalListSorted, func(i, j int) bool {
+		if terminalListSorted[i].Distance != terminalListSorted[j].Distance {
+			return terminalListSorted[i].Distance < terminalListSorted[j].Distance
+		}
+		return terminalListSorted[i].ID < terminalListSorted[j].ID
 	})
 
-	res = dto.GetAllTerminalResponse{
-		Terminals: terminalListSorted,
+	if query.Limit > 0 && len(terminalListSorted) > query.Limit {
+		terminalListSorted = terminalListSorted[:query.Limit]
 	}
 
+	res.Terminals = terminalListSorted
+
 	return res, nil
 }
 
 /**
- * Get two closes terminal to user
- * ! Deprecated
+ * Get all terminal sorted by nearest to user
+ * ! Deprecated, use nearest terminal instead
+ */
+func (v *viewService) GetAllTerminalSorted(data dto.GetAllTerminalDto, locale common.Locale) (dto.GetAllTerminalResponse, error) {
+	return v.GetNearestTerminal(data.ToNearestTerminalQuery(0), locale)
+}
+
+/**
+ * Get two closes terminal to user, less when there are fewer terminal
+ * ! Deprecated, use nearest terminal instead
+ */
+func (v *viewService) GetTwoClosesTerminal(data dto.GetAllTerminalDto, locale common.Locale) (dto.GetAllTerminalResponse, error) {
+	return v.GetNearestTerminal(data.ToNearestTerminalQuery(2), locale)
+}
+
+/**
+ * Get terminal of a route ordered by its sequence
  */
-func (v *viewService) GetTwoClosesTerminal(data dto.GetAllTerminalDto) (dto.GetAllTerminalResponse, error) {
-	resp, err := v.GetAllTerminalSorted(data)
+func (v *viewService) GetRouteTerminal(route dto.Route, locale common.Locale) (dto.GetRouteTerminalResponse, error) {
+	var (
+		res       = dto.GetRouteTerminalResponse{Route: route, Terminals: make([]dto.TerminalResponse, 0)}
+		terminals = []dto.Terminal{}
+	)
+
+	err := v.application.TerminalService.GetAllByRoute(route, &terminals)
 	if err != nil {
-		return resp, err
+		v.shared.Logger.Errorf("error when finding all terminal by route, err: %s", err.Error())
+		return res, err
 	}
 
-	resp.Terminals = resp.Terminals[0:2]
+	for _, t := range terminals {
+		t.Localize(locale)
+		res.Terminals = append(res.Terminals, t.ToTerminalResponse())
+	}
+
+	return res, nil
+}
+
+func (v *viewService) CreateTerminal(data dto.CreateTerminalDto) (dto.TerminalResponse, error) {
+	terminal := data.ToTerminal()
+
+	places, err := v.findPlaces(data.PlaceIDs)
+	if err != nil {
+		return dto.TerminalResponse{}, err
+	}
+
+	err = v.application.TerminalService.Create(&terminal)
+	if err != nil {
+		v.shared.Logger.Errorf("error when inserting terminal to database, err: %s", err.Error())
+		return dto.TerminalResponse{}, err
+	}
+
+	err = v.replacePlaces(&terminal, places)
+	if err != nil {
+		return dto.TerminalResponse{}, err
+	}
+
+	v.syncStation()
+	v.application.SearchService.Invalidate()
+
+	return terminal.ToTerminalResponse(), nil
+}
+
+/**
+ * Edit terminal detail, route and sequence changed through reorder instead
+ */
+func (v *viewService) EditTerminal(data dto.EditTerminalDto, id string) (dto.TerminalResponse, error) {
+	terminal := dto.Terminal{}
+
+	err := v.application.TerminalService.GetById(id, &terminal)
+	if err != nil {
+		v.shared.Logger.Errorf("error when finding terminal by id, err: %s", err.Error())
+		return dto.TerminalResponse{}, err
+	}
+
+	terminal.FillTerminalEdit(data)
+
+	err = v.application.TerminalService.Save(&terminal)
+	if err != nil {
+		v.shared.Logger.Errorf("error when saving terminal, err: %s", err.Error())
+		return dto.TerminalResponse{}, err
+	}
+
+	if data.PlaceIDs != nil {
+		places, err := v.findPlaces(*data.PlaceIDs)
+		if err != nil {
+			return dto.TerminalResponse{}, err
+		}
+
+		err = v.replacePlaces(&terminal, places)
+		if err != nil {
+			return dto.TerminalResponse{}, err
+		}
+	}
+
+	v.syncStation()
+	v.application.SearchService.Invalidate()
+
+	return terminal.ToTerminalResponse(), nil
+}
+
+func (v *viewService) DeleteTerminal(id string) error {
+	terminal := dto.Terminal{}
+
+	err := v.application.TerminalService.GetById(id, &terminal)
+	if err != nil {
+		v.shared.Logger.Errorf("error when finding terminal by id, err: %s", err.Error())
+		return err
+	}
+
+	err = v.application.TerminalService.Delete(&terminal)
+	if err != nil {
+		v.shared.Logger.Errorf("error when deleting terminal, err: %s", err.Error())
+		return err
+	}
+
+	v.syncStation()
+	v.application.SearchService.Invalidate()
+
+	return nil
+}
+
+/**
+ * Reorder terminal of a route
+ * * the id list must contain every terminal of the route exactly once
+ */
+func (v *viewService) ReorderTerminal(data dto.ReorderTerminalDto) (dto.GetRouteTerminalResponse, error) {
+	terminals := []dto.Terminal{}
+
+	err := v.application.TerminalService.GetAllByRoute(data.Route, &terminals)
+	if err != nil {
+		v.shared.Logger.Errorf("error when finding all terminal by route, err: %s", err.Error())
+		return dto.GetRouteTerminalResponse{}, err
+	}
+
+	current := make(map[uint]bool, len(terminals))
+	for _, t := range terminals {
+		current[t.ID] = true
+	}
+
+	if len(data.TerminalIDs) != len(current) {
+		return dto.GetRouteTerminalResponse{}, errors.New("terminal list must contain every terminal of the route")
+	}
+	for _, id := range data.TerminalIDs {
+		if !current[id] {
+			return dto.GetRouteTerminalResponse{}, errors.New("terminal is not on the route")
+		}
+	}
+
+	err = v.application.TerminalService.Reorder(data.Route, data.TerminalIDs)
+	if err != nil {
+		v.shared.Logger.Errorf("error when reordering terminal, err: %s", err.Error())
+		return dto.GetRouteTerminalResponse{}, err
+	}
+
+	return v.GetRouteTerminal(data.Route, common.DEFAULTLOCALE)
+}
+
+/**
+ * Station follow terminal name, failure only logged since the terminal already saved
+ */
+func (v *viewService) syncStation() {
+	err := v.application.StationService.Sync(v.shared.DB)
+	if err != nil {
+		v.shared.Logger.Errorf("error when syncing station, err: %s", err.Error())
+	}
+}
+
+func (v *viewService) findPlaces(ids []uint) ([]dto.Place, error) {
+	places := []dto.Place{}
+
+	err := v.application.PlaceService.FindByIds(ids, &places)
+	if err != nil {
+		v.shared.Logger.Errorf("error when finding place by ids, err: %s", err.Error())
+		return places, err
+	}
+
+	if len(places) != len(ids) {
+		return places, errors.New("place not found")
+	}
+
+	return places, nil
+}
+
+func (v *viewService) replacePlaces(terminal *dto.Terminal, places []dto.Place) error {
+	err := v.application.TerminalService.ReplacePlaces(v.shared.DB, terminal, places)
+	if err != nil {
+		v.shared.Logger.Errorf("error when replacing terminal place, err: %s", err.Error())
+		return err
+	}
 
-	return resp, nil
+	terminal.Places = places
+	return nil
 }
 
-func (v *viewService) getTerminalDistance(data dto.GetAllTerminalDto, terminal dto.Terminal, next string) dto.TerminalListWithDistance {
+func (v *viewService) getTerminalDistance(query dto.NearestTerminalQuery, terminal dto.Terminal) dto.TerminalListWithDistance {
 	res := dto.TerminalListWithDistance{
 		ID:    terminal.ID,
 		Name:  terminal.Name,
 		Route: terminal.Route,
-		Next:  next,
 	}
 
-	res.Distance = common.Distance(data.Lat, data.Long, terminal.Lat, terminal.Long)
+	res.Distance = common.Distance(query.Lat, query.Long, terminal.Lat, terminal.Long)
+	res.WalkMinute = dto.WalkMinute(res.Distance)
 
 	return res
 }
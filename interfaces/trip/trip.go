@@ -0,0 +1,276 @@
+package trip
+
+import (
+	"errors"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"tracking-server/application"
+	"tracking-server/interfaces/bus"
+	"tracking-server/shared"
+	"tracking-server/shared/common"
+	"tracking-server/shared/dto"
+)
+
+type (
+	ViewService interface {
+		PlanTrip(data dto.PlanTripDto, locale common.Locale) (dto.PlanTripResponse, error)
+	}
+	viewService struct {
+		application application.Holder
+		shared      shared.Holder
+		bus         bus.ViewService
+	}
+)
+
+/**
+ * Plan trip from origin coordinate to a terminal or landmark
+ * Each route ride follows its own terminal order, so route running in opposite
+ * direction reach the same terminal with different ride time
+ * Closed terminal is neither boarded nor alighted at
+ * Option ranked by total of walking, waiting and riding time
+ */
+func (v *viewService) PlanTrip(data dto.PlanTripDto, locale common.Locale) (dto.PlanTripResponse, error) {
+	var (
+		response  = dto.PlanTripResponse{Options: make([]dto.TripOption, 0)}
+		terminals = []dto.Terminal{}
+		options   = make([]dto.TripOption, 0)
+		arrivals  = make(map[uint][]dto.BusInfo)
+		now       = time.Now()
+	)
+
+	err := v.application.TerminalService.GetAllTerminal(&terminals)
+	if err != nil {
+		v.shared.Logger.Errorf("error when getting all terminal, err: %s", err.Error())
+		return response, err
+	}
+
+	destination := findDestination(data, terminals)
+	if len(destination) == 0 {
+		return response, errors.New("destination not found")
+	}
+
+	for _, route := range v.application.RouteService.GetActive() {
+		stops := []dto.Terminal{}
+		err := v.application.TerminalService.GetAllByRoute(route, &stops)
+		if err != nil {
+			v.shared.Logger.Errorf("error when finding all terminal by route, err: %s", err.Error())
+			return response, err
+		}
+		dto.TerminalSlice(stops).Localize(locale)
+
+		path := dto.TerminalSlice(stops).ToRoute()
+		closed := v.application.ClosureService.GetActive(route, now).ClosedIndex(stops)
+
+		for _, board := range boardingTerminal(data, stops, closed) {
+			walk := common.Distance(data.Lat, data.Long, stops[board].Lat, stops[board].Long)
+			walkMinute := walk * 1000 / dto.WALKINGSPEED / 60
+
+			next, ok := v.nextBus(arrivals, stops[board].ID, walkMinute)
+			if !ok {
+				continue
+			}
+
+			for alight := range stops {
+				if alight == board || closed[alight] || !destination[stops[alight].ID] {
+					continue
+				}
+
+				departAt := now.Add(time.Duration(next.Estimate) * time.Minute)
+				ride := v.rideMinute(stops, path, board, alight, departAt)
+
+				options = append(options, dto.TripOption{
+					Route:        route,
+					Board:        stops[board].ToTripTerminal(),
+					Alight:       stops[alight].ToTripTerminal(),
+					Bus:          next.ToTripBus(),
+					WalkDistance: walk,
+					WalkMinute:   int(math.Ceil(walkMinute)),
+					WaitMinute:   int(math.Max(0, float64(next.Estimate)-math.Ceil(walkMinute))),
+					RideMinute:   int(math.Ceil(ride)),
+					Stops:        servedStops(path, board, alight, closed),
+					TotalMinute:  int(math.Ceil(math.Max(walkMinute, float64(next.Estimate)) + ride)),
+				})
+			}
+		}
+	}
+
+	sort.SliceStable(options, func(i, j int) bool {
+		return options[i].TotalMinute < options[j].TotalMinute
+	})
+
+	// keep the fastest option of each boarding terminal
+	boarded := make(map[uint]bool)
+	for _, o := range options {
+		if boarded[o.Board.ID] {
+			continue
+		}
+		boarded[o.Board.ID] = true
+
+		response.Options = append(response.Options, o)
+		if len(response.Options) == dto.TRIPMAXOPTION {
+			break
+		}
+	}
+
+	return response, nil
+}
+
+/**
+ * First bus reaching the terminal after the rider walked there
+ */
+func (v *viewService) nextBus(arrivals map[uint][]dto.BusInfo, terminalID uint, walkMinute float64) (dto.BusInfo, bool) {
+	buses, ok := arrivals[terminalID]
+	if !ok {
+		info, err := v.bus.BusInfo(strconv.FormatUint(uint64(terminalID), 10), common.DEFAULTLOCALE)
+		if err != nil {
+			return dto.BusInfo{}, false
+		}
+		buses = info.Bus
+		arrivals[terminalID] = buses
+	}
+
+	// bus info already sorted by estimate
+	for _, b := range buses {
+		if float64(b.Estimate) >= walkMinute {
+			return b, true
+		}
+	}
+
+	return dto.BusInfo{}, false
+}
+
+/**
+ * Ride time in minute from boarding to alighting terminal following the route order
+ * Use historical segment travel time, fallback to nominal speed
+ */
+func (v *viewService) rideMinute(stops []dto.Terminal, path common.Route, board int, alight int, at time.Time) float64 {
+	var seconds float64
+
+	for i := board; i != alight; i = path.Next(i) {
+		next := path.Next(i)
+		t, ok := v.application.TravelTimeService.SegmentTime(stops[i].ID, stops[next].ID, at.Add(time.Duration(seconds)*time.Second))
+		if !ok {
+			t = path.Segments[i] * 1000 / dto.NOMINALBUSSPEED
+		}
+		seconds += t
+	}
+
+	return seconds / 60
+}
+
+/**
+ * Number of stop from boarding to alighting terminal, closed terminal not counted
+ */
+func servedStops(path common.Route, board int, alight int, closed map[int]bool) int {
+	stops := 0
+	for i := path.Next(board); i != alight; i = path.Next(i) {
+		if !closed[i] {
+			stops++
+		}
+	}
+	return stops + 1
+}
+
+/**
+ * Terminal matching the destination on every route
+ * Terminal id also match terminal with the same name on the other route
+ */
+func findDestination(data dto.PlanTripDto, terminals []dto.Terminal) map[uint]bool {
+	var (
+		res   = make(map[uint]bool)
+		query = strings.ToLower(strings.TrimSpace(data.Destination))
+	)
+
+	if data.TerminalID != 0 {
+		for _, t := range terminals {
+			if t.ID == data.TerminalID {
+				query = strings.ToLower(t.Name)
+			}
+		}
+		if query == "" {
+			return res
+		}
+		for _, t := range terminals {
+			if strings.ToLower(t.Name) == query {
+				res[t.ID] = true
+			}
+		}
+		return res
+	}
+
+	if query == "" {
+		return res
+	}
+
+	for _, t := range terminals {
+		if strings.Contains(strings.ToLower(t.Name), query) || (t.NameEn != "" && strings.Contains(strings.ToLower(t.NameEn), query)) {
+			res[t.ID] = true
+			continue
+		}
+		for _, place := range t.Places {
+			if matchPlace(place, query) {
+				res[t.ID] = true
+				break
+			}
+		}
+	}
+
+	return res
+}
+
+func matchPlace(place dto.Place, query string) bool {
+	if strings.Contains(strings.ToLower(place.Name), query) {
+		return true
+	}
+	if place.NameEn != "" && strings.Contains(strings.ToLower(place.NameEn), query) {
+		return true
+	}
+	for _, alias := range place.Aliases {
+		if strings.Contains(strings.ToLower(alias.Alias), query) {
+			return true
+		}
+	}
+	return false
+}
+
+/**
+ * Open terminal within walking distance of origin, fallback to the nearest open terminal
+ */
+func boardingTerminal(data dto.PlanTripDto, stops []dto.Terminal, closed map[int]bool) []int {
+	var (
+		res     = make([]int, 0)
+		nearest = -1
+		best    = math.MaxFloat64
+	)
+
+	for i, t := range stops {
+		if closed[i] {
+			continue
+		}
+
+		d := common.Distance(data.Lat, data.Long, t.Lat, t.Long)
+		if d <= dto.TRIPMAXWALK {
+			res = append(res, i)
+		}
+		if d < best {
+			nearest, best = i, d
+		}
+	}
+
+	if len(res) == 0 && nearest >= 0 {
+		res = append(res, nearest)
+	}
+
+	return res
+}
+
+func NewViewService(application application.Holder, shared shared.Holder, bus bus.ViewService) ViewService {
+	return &viewService{
+		application: application,
+		shared:      shared,
+		bus:         bus,
+	}
+}
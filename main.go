@@ -2,9 +2,11 @@ package main
 
 import (
 	"log"
+	"tracking-server/application"
 	"tracking-server/di"
 	"tracking-server/docs"
 	"tracking-server/infrastructure"
+	"tracking-server/interfaces"
 	"tracking-server/shared/config"
 
 	"github.com/gofiber/fiber/v2"
@@ -18,8 +20,11 @@ import (
 func main() {
 	container := di.Container
 
-	err := container.Invoke(func(http *fiber.App, env *config.EnvConfig, holder infrastructure.Holder) error {
+	err := container.Invoke(func(http *fiber.App, env *config.EnvConfig, holder infrastructure.Holder, app application.Holder, view interfaces.Holder) error {
 		infrastructure.Routes(http, holder)
+		application.Seed(app)
+		application.Workers(app)
+		interfaces.Workers(view)
 		if env.ENV == "PROD" {
 			docs.SwaggerInfo.Host = "api.bikunku.com"
 		}
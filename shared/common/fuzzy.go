@@ -0,0 +1,163 @@
+package common
+
+import (
+	"strings"
+	"unicode"
+)
+
+/**
+ * Lowercase text and replace punctuation with space, e.g. "F.Psi" become "f psi"
+ */
+func NormalizeText(text string) string {
+	fields := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	return strings.Join(fields, " ")
+}
+
+/**
+ * Edit allowed for a word to still count as a typo of another word
+ */
+func MaxTypo(word string) int {
+	switch n := len([]rune(word)); {
+	case n <= 3:
+		return 0
+	case n <= 5:
+		return 1
+	default:
+		return 2
+	}
+}
+
+/**
+ * Number of insert, delete, substitute and adjacent swap to turn a into b
+ */
+func EditDistance(a string, b string) int {
+	var (
+		s = []rune(a)
+		t = []rune(b)
+		d = make([][]int, len(s)+1)
+	)
+
+	for i := range d {
+		d[i] = make([]int, len(t)+1)
+		d[i][0] = i
+	}
+	for j := range d[0] {
+		d[0][j] = j
+	}
+
+	for i := 1; i <= len(s); i++ {
+		for j := 1; j <= len(t); j++ {
+			cost := 1
+			if s[i-1] == t[j-1] {
+				cost = 0
+			}
+
+			d[i][j] = minInt(d[i-1][j]+1, d[i][j-1]+1, d[i-1][j-1]+cost)
+
+			if i > 1 && j > 1 && s[i-1] == t[j-2] && s[i-2] == t[j-1] {
+				d[i][j] = minInt(d[i][j], d[i-2][j-2]+1)
+			}
+		}
+	}
+
+	return d[len(s)][len(t)]
+}
+
+/**
+ * Score how well normalized query match a normalized key, 0 when not matching
+ * Exact match score highest, then prefix, word prefix, substring and typo tolerant match
+ */
+func MatchScore(query string, key string) float64 {
+	if query == "" || key == "" {
+		return 0
+	}
+
+	compactQuery := strings.ReplaceAll(query, " ", "")
+	compactKey := strings.ReplaceAll(key, " ", "")
+
+	switch {
+	case key == query || compactKey == compactQuery:
+		return 100
+	case strings.HasPrefix(key, query) || strings.HasPrefix(compactKey, compactQuery):
+		return 90
+	}
+
+	var (
+		words  = strings.Fields(query)
+		keys   = strings.Fields(key)
+		prefix = true
+		typo   = 0
+	)
+
+	for _, w := range words {
+		matched, edit := false, MaxTypo(w)+1
+
+		for _, k := range keys {
+			if strings.HasPrefix(k, w) {
+				matched, edit = true, 0
+				break
+			}
+
+			if e := wordEdit(w, k); e <= MaxTypo(w) && e < edit {
+				matched, edit = true, e
+			}
+		}
+
+		if !matched {
+			return substringScore(compactQuery, compactKey)
+		}
+		if edit > 0 {
+			prefix = false
+			typo += edit
+		}
+	}
+
+	if prefix {
+		return 80
+	}
+
+	return maxFloat(60-10*float64(typo), substringScore(compactQuery, compactKey))
+}
+
+/**
+ * Typo distance of a query word to a key word, the key word may be longer
+ * since the rider may still be typing it
+ */
+func wordEdit(word string, key string) int {
+	best := EditDistance(word, key)
+
+	r := []rune(key)
+	if n := len([]rune(word)); n < len(r) {
+		if e := EditDistance(word, string(r[:n])); e < best {
+			best = e
+		}
+	}
+
+	return best
+}
+
+func substringScore(query string, key string) float64 {
+	if len(query) >= 3 && strings.Contains(key, query) {
+		return 70
+	}
+	return 0
+}
+
+func minInt(values ...int) int {
+	res := values[0]
+	for _, v := range values[1:] {
+		if v < res {
+			res = v
+		}
+	}
+	return res
+}
+
+func maxFloat(a float64, b float64) float64 {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -3,7 +3,6 @@ package common
 import (
 	"errors"
 
-	"github.com/go-playground/validator/v10"
 	"github.com/gofiber/fiber/v2"
 )
 
@@ -21,7 +20,6 @@ func DoCommonRequest(ctx *fiber.Ctx, body interface{}) error {
 		return errors.New("failed to parse body")
 	}
 
-	validate := validator.New()
 	err = validate.Struct(body)
 	if err != nil {
 		return err
@@ -30,6 +28,20 @@ func DoCommonRequest(ctx *fiber.Ctx, body interface{}) error {
 	return nil
 }
 
+func DoCommonQuery(ctx *fiber.Ctx, query interface{}) error {
+	err := ctx.QueryParser(query)
+	if err != nil {
+		return errors.New("failed to parse query")
+	}
+
+	err = validate.Struct(query)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func DoCommonSuccessResponse(ctx *fiber.Ctx, data interface{}) error {
 	return ctx.Status(fiber.StatusOK).JSON(Response{
 		Status: "OK",
@@ -37,9 +49,12 @@ func DoCommonSuccessResponse(ctx *fiber.Ctx, data interface{}) error {
 	})
 }
 
+/**
+ * Error message localized by the locale asked by the request, english otherwise
+ */
 func DoCommonErrorResponse(ctx *fiber.Ctx, err error) error {
 	return ctx.Status(fiber.StatusBadRequest).JSON(Response{
 		Status: "FAILED",
-		Error:  err.Error(),
+		Error:  TranslateError(err, GetErrorLocale(ctx)),
 	})
 }
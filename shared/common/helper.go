@@ -1,10 +1,16 @@
 package common
 
 import (
+	"crypto/rand"
+	"encoding/hex"
 	"math"
 )
 
 func Distance(lat1 float64, lng1 float64, lat2 float64, lng2 float64) float64 {
+	return math.Round(distance(lat1, lng1, lat2, lng2)*100) / 100
+}
+
+func distance(lat1 float64, lng1 float64, lat2 float64, lng2 float64) float64 {
 	const PI float64 = 3.141592653589793
 
 	radlat1 := float64(PI * lat1 / 180)
@@ -24,5 +30,24 @@ func Distance(lat1 float64, lng1 float64, lat2 float64, lng2 float64) float64 {
 	dist = dist * 60 * 1.1515
 	dist = dist * 1.609344
 
-	return math.Round(dist*100) / 100
+	return dist
+}
+
+func Bearing(lat1 float64, lng1 float64, lat2 float64, lng2 float64) float64 {
+	radlat1 := lat1 * math.Pi / 180
+	radlat2 := lat2 * math.Pi / 180
+	radtheta := (lng2 - lng1) * math.Pi / 180
+
+	y := math.Sin(radtheta) * math.Cos(radlat2)
+	x := math.Cos(radlat1)*math.Sin(radlat2) - math.Sin(radlat1)*math.Cos(radlat2)*math.Cos(radtheta)
+
+	return math.Mod(math.Atan2(y, x)*180/math.Pi+360, 360)
+}
+
+func RandomToken(size int) (string, error) {
+	b := make([]byte, size)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
 }
@@ -1,6 +1,7 @@
 package common
 
 import (
+	"errors"
 	"fmt"
 	"time"
 	"tracking-server/shared/config"
@@ -8,6 +9,8 @@ import (
 	"github.com/golang-jwt/jwt"
 )
 
+var ErrUnexpectedSigningMethod = errors.New("unexpected signing method")
+
 func NewJWT(username string, env *config.EnvConfig) (string, error) {
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.StandardClaims{
 		ExpiresAt: time.Now().Add(time.Hour * 8).Unix(),
@@ -32,11 +35,17 @@ func NewJWTWithID(username string, id string, env *config.EnvConfig) (string, er
 func parseJWT(tokenString string, env *config.EnvConfig) (*jwt.Token, error) {
 	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
 		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+			return nil, fmt.Errorf("%w: %v", ErrUnexpectedSigningMethod, token.Header["alg"])
 		}
 		return []byte(env.JWTSecret), nil
 	})
 
+	// validation error does not unwrap, return the sentinel so it can be translated
+	var invalid *jwt.ValidationError
+	if errors.As(err, &invalid) && errors.Is(invalid.Inner, ErrUnexpectedSigningMethod) {
+		return nil, invalid.Inner
+	}
+
 	if err != nil {
 		return nil, err
 	}
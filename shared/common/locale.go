@@ -0,0 +1,125 @@
+package common
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	ID Locale = "id"
+	EN Locale = "en"
+
+	// Content is written in indonesian, english field fall back to it
+	DEFAULTLOCALE = ID
+
+	// Error message stay english unless the client ask for a locale,
+	// so client matching on the error text keep working
+	DEFAULTERRORLOCALE = EN
+)
+
+type Locale string
+
+/**
+ * Locale of the request from lang query param, then Accept-Language header,
+ * fallback to the default locale
+ */
+func GetLocale(ctx *fiber.Ctx) Locale {
+	if locale, ok := requestLocale(ctx); ok {
+		return locale
+	}
+	return DEFAULTLOCALE
+}
+
+/**
+ * Locale of the error message, english unless the request ask for a locale
+ */
+func GetErrorLocale(ctx *fiber.Ctx) Locale {
+	if locale, ok := requestLocale(ctx); ok {
+		return locale
+	}
+	return DEFAULTERRORLOCALE
+}
+
+func requestLocale(ctx *fiber.Ctx) (Locale, bool) {
+	if locale, ok := ParseLocale(ctx.Query("lang")); ok {
+		return locale, true
+	}
+	return parseAcceptLanguage(ctx.Get(fiber.HeaderAcceptLanguage))
+}
+
+/**
+ * Supported locale of a language tag, e.g. en-US become en
+ */
+func ParseLocale(tag string) (Locale, bool) {
+	primary := strings.ToLower(strings.TrimSpace(tag))
+	if i := strings.IndexAny(primary, "-_"); i >= 0 {
+		primary = primary[:i]
+	}
+
+	switch Locale(primary) {
+	case ID, EN:
+		return Locale(primary), true
+	case "in":
+		// legacy code of indonesian still sent by older android
+		return ID, true
+	}
+	return DEFAULTLOCALE, false
+}
+
+/**
+ * Pick supported language with the highest quality from Accept-Language
+ */
+func parseAcceptLanguage(header string) (Locale, bool) {
+	type weighted struct {
+		locale  Locale
+		quality float64
+	}
+
+	candidates := make([]weighted, 0)
+
+	for _, part := range strings.Split(header, ",") {
+		fields := strings.Split(part, ";")
+
+		locale, ok := ParseLocale(fields[0])
+		if !ok {
+			continue
+		}
+
+		quality := 1.0
+		for _, f := range fields[1:] {
+			f = strings.TrimSpace(f)
+			if strings.HasPrefix(f, "q=") {
+				if q, err := strconv.ParseFloat(f[2:], 64); err == nil {
+					quality = q
+				}
+			}
+		}
+
+		if quality > 0 {
+			candidates = append(candidates, weighted{locale, quality})
+		}
+	}
+
+	if len(candidates) == 0 {
+		return DEFAULTLOCALE, false
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].quality > candidates[j].quality
+	})
+
+	return candidates[0].locale, true
+}
+
+/**
+ * Localized text, english fall back to the default text when not translated
+ */
+func Localize(locale Locale, text string, english string) string {
+	if locale == EN && english != "" {
+		return english
+	}
+	return text
+}
@@ -0,0 +1,131 @@
+package common
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/go-playground/locales/en"
+	"github.com/go-playground/locales/id"
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+	en_translations "github.com/go-playground/validator/v10/translations/en"
+	id_translations "github.com/go-playground/validator/v10/translations/id"
+	"gorm.io/gorm"
+)
+
+var (
+	translator = ut.New(en.New(), en.New(), id.New())
+
+	// Indonesian message of error returned to client, keyed by the english message
+	messages = map[string]string{
+		"failed to parse body":                                   "gagal membaca body",
+		"failed to parse query":                                  "gagal membaca query",
+		"record not found":                                       "data tidak ditemukan",
+		"bus not found":                                          "bus tidak ditemukan",
+		"bus is not active":                                      "bus tidak aktif",
+		"experimentalId is required":                             "experimentalId wajib diisi",
+		"sandbox not found":                                      "sandbox tidak ditemukan",
+		"invalid sandbox token":                                  "token sandbox tidak valid",
+		"destination not found":                                  "tujuan tidak ditemukan",
+		"subscription expired":                                   "langganan sudah kedaluwarsa",
+		"notification webhook is not configured":                 "webhook notifikasi belum dikonfigurasi",
+		"too many report, try again later":                       "terlalu banyak laporan, coba lagi nanti",
+		"window end must be after start":                         "jam selesai harus setelah jam mulai",
+		"end date must not be before start date":                 "tanggal selesai tidak boleh sebelum tanggal mulai",
+		"route already exist":                                    "rute sudah ada",
+		"route is still used by bus or terminal":                 "rute masih digunakan oleh bus atau terminal",
+		"route has no shape":                                     "rute belum memiliki bentuk jalur",
+		"invalid encoded polyline":                               "encoded polyline tidak valid",
+		"geojson must contain a LineString":                      "geojson harus berisi LineString",
+		"shape coordinate out of range":                          "koordinat bentuk jalur di luar jangkauan",
+		"terminal is not on the route":                           "terminal tidak berada di rute",
+		"terminal list must contain every terminal of the route": "daftar terminal harus berisi semua terminal di rute",
+		"place not found":                                        "tempat tidak ditemukan",
+		"terminal not found":                                     "terminal tidak ditemukan",
+		"station is not served by any active route":              "halte tidak dilayani oleh rute aktif mana pun",
+		"end time must be after start time":                      "waktu selesai harus setelah waktu mulai",
+		"closure must close a terminal or carry a detour":        "penutupan harus menutup terminal atau memiliki jalur pengalihan",
+	}
+
+	// Indonesian message of error carrying detail, matched by the wrapped sentinel error
+	sentinels = map[error]string{
+		ErrUnexpectedSigningMethod: "metode tanda tangan tidak dikenali",
+	}
+)
+
+/**
+ * Register english and indonesian message of every built in validation tag
+ */
+func init() {
+	english, _ := translator.GetTranslator(string(EN))
+	if err := en_translations.RegisterDefaultTranslations(validate, english); err != nil {
+		panic(err)
+	}
+
+	indonesian, _ := translator.GetTranslator(string(ID))
+	if err := id_translations.RegisterDefaultTranslations(validate, indonesian); err != nil {
+		panic(err)
+	}
+}
+
+/**
+ * Register message of a custom validation tag, {0} replaced by the field name
+ */
+func RegisterTranslation(tag string, message map[Locale]string) error {
+	for locale, text := range message {
+		trans, _ := translator.GetTranslator(string(locale))
+
+		text := text
+		err := validate.RegisterTranslation(tag, trans, func(ut ut.Translator) error {
+			return ut.Add(tag, text, true)
+		}, func(ut ut.Translator, fe validator.FieldError) string {
+			t, _ := ut.T(tag, fe.Field())
+			return t
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+/**
+ * Register indonesian message of a sentinel error, detail wrapped after it kept as is
+ * Only called on package init
+ */
+func RegisterErrorTranslation(sentinel error, message string) {
+	sentinels[sentinel] = message
+}
+
+/**
+ * Message of an error in the locale, validation error translated per field
+ * Message without translation returned as is
+ */
+func TranslateError(err error, locale Locale) string {
+	var invalid validator.ValidationErrors
+	if errors.As(err, &invalid) {
+		trans, _ := translator.GetTranslator(string(locale))
+
+		res := make([]string, 0, len(invalid))
+		for _, fe := range invalid {
+			res = append(res, fe.Translate(trans))
+		}
+		return strings.Join(res, ", ")
+	}
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		err = gorm.ErrRecordNotFound
+	}
+
+	if locale == ID {
+		if text, ok := messages[err.Error()]; ok {
+			return text
+		}
+		for sentinel, text := range sentinels {
+			if errors.Is(err, sentinel) {
+				return text + strings.TrimPrefix(err.Error(), sentinel.Error())
+			}
+		}
+	}
+	return err.Error()
+}
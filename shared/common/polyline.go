@@ -0,0 +1,113 @@
+package common
+
+import (
+	"errors"
+	"math"
+	"strings"
+)
+
+// Precision of encoded polyline coordinate, 5 decimal place
+const polylineFactor = 1e5
+
+/**
+ * Encode point into google encoded polyline format
+ */
+func EncodePolyline(points []Point) string {
+	var (
+		b         strings.Builder
+		lat, long int64
+		prevLat   int64
+		prevLong  int64
+	)
+
+	for _, p := range points {
+		lat = int64(math.Round(p.Lat * polylineFactor))
+		long = int64(math.Round(p.Long * polylineFactor))
+
+		encodePolylineValue(&b, lat-prevLat)
+		encodePolylineValue(&b, long-prevLong)
+
+		prevLat, prevLong = lat, long
+	}
+
+	return b.String()
+}
+
+/**
+ * Decode google encoded polyline into point
+ */
+func DecodePolyline(encoded string) ([]Point, error) {
+	var (
+		points    = make([]Point, 0)
+		lat, long int64
+		index     = 0
+	)
+
+	for index < len(encoded) {
+		dlat, next, err := decodePolylineValue(encoded, index)
+		if err != nil {
+			return nil, err
+		}
+
+		dlong, next, err := decodePolylineValue(encoded, next)
+		if err != nil {
+			return nil, err
+		}
+
+		index = next
+		lat += dlat
+		long += dlong
+
+		points = append(points, Point{
+			Lat:  float64(lat) / polylineFactor,
+			Long: float64(long) / polylineFactor,
+		})
+	}
+
+	return points, nil
+}
+
+func encodePolylineValue(b *strings.Builder, value int64) {
+	v := value << 1
+	if value < 0 {
+		v = ^v
+	}
+
+	for v >= 0x20 {
+		b.WriteByte(byte((0x20 | (v & 0x1f)) + 63))
+		v >>= 5
+	}
+	b.WriteByte(byte(v + 63))
+}
+
+func decodePolylineValue(encoded string, index int) (int64, int, error) {
+	var (
+		result int64
+		shift  uint
+	)
+
+	for {
+		if index >= len(encoded) {
+			return 0, index, errors.New("invalid encoded polyline")
+		}
+
+		c := int64(encoded[index]) - 63
+		index++
+
+		if c < 0 || shift > 60 {
+			return 0, index, errors.New("invalid encoded polyline")
+		}
+
+		result |= (c & 0x1f) << shift
+		shift += 5
+
+		if c < 0x20 {
+			break
+		}
+	}
+
+	if result&1 != 0 {
+		return ^(result >> 1), index, nil
+	}
+	return result >> 1, index, nil
+}
@@ -0,0 +1,166 @@
+package common
+
+import "math"
+
+type (
+	// Point coordinate on a route
+	Point struct {
+		Lat  float64
+		Long float64
+	}
+
+	// Route ordered stop of a loop route, last stop connected back to the first
+	Route struct {
+		Points   []Point
+		Segments []float64
+		Length   float64
+	}
+
+	// RouteProjection position of a coordinate projected to the nearest route segment
+	RouteProjection struct {
+		Segment  int
+		Offset   float64
+		Distance float64
+	}
+)
+
+/**
+ * Build loop route and precompute segment length in km
+ */
+func NewRoute(points []Point) Route {
+	route := Route{
+		Points:   points,
+		Segments: make([]float64, len(points)),
+	}
+
+	for i := range points {
+		next := points[(i+1)%len(points)]
+		route.Segments[i] = distance(points[i].Lat, points[i].Long, next.Lat, next.Long)
+		route.Length += route.Segments[i]
+	}
+
+	return route
+}
+
+func (r *Route) Next(index int) int {
+	if len(r.Points) == 0 {
+		return 0
+	}
+	return (index + 1) % len(r.Points)
+}
+
+/**
+ * Project coordinate to the nearest segment of the route
+ * Segment is the index of the stop the segment starts from, offset is km travelled on that segment
+ */
+func (r *Route) Project(lat float64, long float64) RouteProjection {
+	return r.ProjectWithin(lat, long, 0, len(r.Points))
+}
+
+/**
+ * Project coordinate only to segment starting from index up to count segment ahead
+ * Used to keep the projection moving forward when the road pass close to another segment
+ */
+func (r *Route) ProjectWithin(lat float64, long float64, start int, count int) RouteProjection {
+	var (
+		best = RouteProjection{Distance: math.MaxFloat64}
+	)
+
+	for n, i := 0, start; n < count && n < len(r.Points); n, i = n+1, r.Next(i) {
+		from := r.Points[i]
+		to := r.Points[r.Next(i)]
+
+		ratio, dist := projectToSegment(lat, long, from, to)
+		if dist < best.Distance {
+			best = RouteProjection{
+				Segment:  i,
+				Offset:   ratio * r.Segments[i],
+				Distance: dist,
+			}
+		}
+	}
+
+	return best
+}
+
+/**
+ * Number of stop from index to target following the route direction
+ */
+func (r *Route) StopsBetween(index int, target int) int {
+	if len(r.Points) == 0 {
+		return 0
+	}
+	return (target - index + len(r.Points)) % len(r.Points)
+}
+
+/**
+ * Distance in km travelled along the route from projected position to the target stop
+ * Wrap around the loop when the target is behind the position
+ */
+func (r *Route) DistanceTo(from RouteProjection, target int) float64 {
+	if len(r.Points) == 0 || target < 0 || target >= len(r.Points) || from.Segment >= len(r.Points) {
+		return 0
+	}
+
+	dist := r.Segments[from.Segment] - from.Offset
+	for i, n := r.Next(from.Segment), 0; i != target && n < len(r.Points); i, n = r.Next(i), n+1 {
+		dist += r.Segments[i]
+	}
+
+	return math.Max(dist, 0)
+}
+
+/**
+ * Project point to segment using local equirectangular approximation
+ * Return ratio along the segment and distance in km from point to the projection
+ */
+func projectToSegment(lat float64, long float64, from Point, to Point) (float64, float64) {
+	const kmPerDegree = 111.32
+
+	scale := math.Cos(from.Lat * math.Pi / 180)
+
+	px := (long - from.Long) * scale * kmPerDegree
+	py := (lat - from.Lat) * kmPerDegree
+	sx := (to.Long - from.Long) * scale * kmPerDegree
+	sy := (to.Lat - from.Lat) * kmPerDegree
+
+	ratio := 0.0
+	if length := sx*sx + sy*sy; length > 0 {
+		ratio = math.Max(0, math.Min(1, (px*sx+py*sy)/length))
+	}
+
+	dx := px - ratio*sx
+	dy := py - ratio*sy
+
+	return ratio, math.Sqrt(dx*dx + dy*dy)
+}
+
+/**
+ * Straight line distance in km from coordinate to a stop
+ */
+func (r *Route) DistanceToStop(lat float64, long float64, index int) float64 {
+	return distance(lat, long, r.Points[index].Lat, r.Points[index].Long)
+}
+
+/**
+ * Index of the stop nearest to the coordinate within the radius in km, -1 when none
+ * * skip exclude stop by index, nil consider every stop
+ */
+func (r *Route) NearestStop(lat float64, long float64, radius float64, skip func(int) bool) int {
+	var (
+		index = -1
+		best  = radius
+	)
+
+	for i := range r.Points {
+		if skip != nil && skip(i) {
+			continue
+		}
+		if d := r.DistanceToStop(lat, long, i); d <= radius && (index < 0 || d < best) {
+			index = i
+			best = d
+		}
+	}
+
+	return index
+}
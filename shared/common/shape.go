@@ -0,0 +1,64 @@
+package common
+
+import "math"
+
+type (
+	// Shape ordered coordinate of the road a route actually drives through
+	Shape struct {
+		Points    []Point
+		Distances []float64
+		Length    float64
+	}
+
+	// ShapeProjection position of a coordinate projected to the nearest shape segment
+	ShapeProjection struct {
+		Segment  int
+		Along    float64
+		Distance float64
+	}
+)
+
+/**
+ * Build shape and precompute distance in km from the first point to every point
+ */
+func NewShape(points []Point) Shape {
+	shape := Shape{
+		Points:    points,
+		Distances: make([]float64, len(points)),
+	}
+
+	for i := 1; i < len(points); i++ {
+		shape.Distances[i] = shape.Distances[i-1] + distance(points[i-1].Lat, points[i-1].Long, points[i].Lat, points[i].Long)
+	}
+
+	if len(points) > 0 {
+		shape.Length = shape.Distances[len(points)-1]
+	}
+
+	return shape
+}
+
+/**
+ * Project coordinate to the nearest shape segment
+ * Along is km travelled along the shape up to the projection, distance is km from the coordinate to the shape
+ */
+func (s *Shape) Project(lat float64, long float64) ShapeProjection {
+	best := ShapeProjection{Distance: math.MaxFloat64}
+
+	if len(s.Points) == 1 {
+		return ShapeProjection{Distance: distance(lat, long, s.Points[0].Lat, s.Points[0].Long)}
+	}
+
+	for i := 0; i < len(s.Points)-1; i++ {
+		ratio, dist := projectToSegment(lat, long, s.Points[i], s.Points[i+1])
+		if dist < best.Distance {
+			best = ShapeProjection{
+				Segment:  i,
+				Along:    s.Distances[i] + ratio*(s.Distances[i+1]-s.Distances[i]),
+				Distance: dist,
+			}
+		}
+	}
+
+	return best
+}
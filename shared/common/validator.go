@@ -0,0 +1,22 @@
+package common
+
+import "github.com/go-playground/validator/v10"
+
+var (
+	validate = validator.New()
+)
+
+/**
+ * Register custom validation tag used by request body and query
+ * Must be called before serving request
+ */
+func RegisterValidation(tag string, fn validator.Func) error {
+	return validate.RegisterValidation(tag, fn)
+}
+
+/**
+ * Validate struct outside of request handling, e.g. data file
+ */
+func ValidateStruct(s interface{}) error {
+	return validate.Struct(s)
+}
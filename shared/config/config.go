@@ -6,16 +6,28 @@ import (
 )
 
 type EnvConfig struct {
-	PORT         string `mapstructure:"PORT"`
-	DBHost       string `mapstructure:"DB_HOST"`
-	DBUser       string `mapstructure:"DB_USER"`
-	DBPassword   string `mapstructure:"DB_PASSWORD"`
-	DBName       string `mapstructure:"DB_NAME"`
-	DBPort       string `mapstructure:"DB_PORT"`
-	JWTSecret    string `mapstructure:"JWT_SECRET"`
-	ENV          string `mapstructure:"ENV"`
-	Experimental string `mapstructure:"EXPERIMENTAL"`
-	GoogleApplicationCredentials string `mapstructure:"GOOGLE_APPLICATION_CREDENTIALS"`
+	PORT                         string  `mapstructure:"PORT"`
+	DBHost                       string  `mapstructure:"DB_HOST"`
+	DBUser                       string  `mapstructure:"DB_USER"`
+	DBPassword                   string  `mapstructure:"DB_PASSWORD"`
+	DBName                       string  `mapstructure:"DB_NAME"`
+	DBPort                       string  `mapstructure:"DB_PORT"`
+	JWTSecret                    string  `mapstructure:"JWT_SECRET"`
+	ENV                          string  `mapstructure:"ENV"`
+	Experimental                 string  `mapstructure:"EXPERIMENTAL"`
+	GoogleApplicationCredentials string  `mapstructure:"GOOGLE_APPLICATION_CREDENTIALS"`
+	SimulatorBusPerRoute         int     `mapstructure:"SIMULATOR_BUS_PER_ROUTE"`
+	SimulatorSpeed               float64 `mapstructure:"SIMULATOR_SPEED"`
+	SimulatorDwell               int     `mapstructure:"SIMULATOR_DWELL"`
+	TravelTimeHistoryDays        int     `mapstructure:"TRAVEL_TIME_HISTORY_DAYS"`
+	TravelTimeRefreshInterval    int     `mapstructure:"TRAVEL_TIME_REFRESH_INTERVAL"`
+	GeofenceRadius               float64 `mapstructure:"GEOFENCE_RADIUS"`
+	HeadwayMin                   float64 `mapstructure:"HEADWAY_MIN"`
+	HeadwayMax                   float64 `mapstructure:"HEADWAY_MAX"`
+	HeadwayInterval              int     `mapstructure:"HEADWAY_INTERVAL"`
+	SubscriptionInterval         int     `mapstructure:"SUBSCRIPTION_INTERVAL"`
+	NotificationWebhookURL       string  `mapstructure:"NOTIFICATION_WEBHOOK_URL"`
+	NetworkFile                  string  `mapstructure:"NETWORK_FILE"`
 }
 
 func NewEnvConfig(log *logrus.Logger) (*EnvConfig, error) {
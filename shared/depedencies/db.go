@@ -33,29 +33,57 @@ func NewDatabase(env *config.EnvConfig, log *logrus.Logger) *gorm.DB {
 
 	migrateSchema(db, log)
 
-	seedDatabase(db)
-
 	return db
 }
 
 func migrateSchema(db *gorm.DB, log *logrus.Logger) {
 	err := db.AutoMigrate(
+		&dto.BusRoute{},
 		&dto.Bus{},
 		&dto.News{},
+		&dto.Place{},
+		&dto.PlaceAlias{},
+		&dto.Station{},
 		&dto.Terminal{},
+		&dto.Closure{},
 		&dto.BusLocation{},
+		&dto.Sandbox{},
+		&dto.SandboxBus{},
+		&dto.SegmentTravelTime{},
+		&dto.TerminalEvent{},
+		&dto.ArrivalSubscription{},
+		&dto.OperatingWindow{},
+		&dto.ServiceException{},
+		&dto.CrowdStatusChange{},
+		&dto.RiderReport{},
+		&dto.ShapePoint{},
+		&dto.NetworkImport{},
 	)
 
 	if err != nil {
 		log.Errorf("error migrateing schema, err: %s", err.Error())
 	}
 
+	backfillTerminalSequence(db, log)
+
 	log.Infoln("database migrated")
 }
 
-func seedDatabase(db *gorm.DB) {
-	terminal := dto.Terminal{}
-	db.Create(terminal.Seeder())
+/**
+ * Terminal created before sequence existed were ordered by id,
+ * keep that order for route which has no sequence yet
+ */
+func backfillTerminalSequence(db *gorm.DB, log *logrus.Logger) {
+	err := db.Exec(`
+		UPDATE terminals t SET sequence = o.sequence
+		FROM (SELECT id, ROW_NUMBER() OVER (PARTITION BY route ORDER BY id) AS sequence FROM terminals) o
+		WHERE t.id = o.id
+		AND NOT EXISTS (SELECT 1 FROM terminals s WHERE s.route = t.route AND s.sequence > 0)
+	`).Error
+
+	if err != nil {
+		log.Errorf("error backfilling terminal sequence, err: %s", err.Error())
+	}
 }
 
 func setConnectionConfiguration(db *gorm.DB) {
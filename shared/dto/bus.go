@@ -7,10 +7,6 @@ import (
 	"github.com/gofiber/websocket/v2"
 )
 
-var (
-	ExperimentalBusLocation sync.Map
-)
-
 const (
 	// Crowded Status
 	EMPTY    BusStatus = "EMPTY"
@@ -25,6 +21,13 @@ const (
 	DRIVER WSType = "driver"
 
 	DEFAULTBUSSPEED = 1.0
+
+	// Speed in m/s used for planning segment without travel time history
+	NOMINALBUSSPEED = 5.0
+
+	// Firebase stream window, only recent location documents are listened to
+	FIREBASESTREAMWINDOW = 5 * time.Minute
+	FIREBASESTREAMLIMIT  = 200
 )
 
 type (
@@ -60,7 +63,7 @@ type (
 	CreateBusDto struct {
 		Number   int    `json:"number" validate:"required"`
 		Plate    string `json:"plate" validate:"required"`
-		Route    Route  `json:"route" validate:"required,oneof=RED BLUE"`
+		Route    Route  `json:"route" validate:"required,route"`
 		Username string `json:"username" validate:"required"`
 		Password string `json:"password" validate:"required"`
 	}
@@ -98,7 +101,7 @@ type (
 		Number   int       `json:"number" validate:"omitempty"`
 		Plate    string    `json:"plate" validate:"omitempty"`
 		Status   BusStatus `json:"status" validate:"omitempty,oneof=EMPTY MODERATE FULL"`
-		Route    Route     `json:"route" validate:"omitempty,oneof=RED BLUE"`
+		Route    Route     `json:"route" validate:"omitempty,route"`
 		IsActive bool      `json:"isActive" validate:"omitempty"`
 	}
 
@@ -112,11 +115,24 @@ type (
 		IsActive bool      `json:"isActive"`
 	}
 
+	// FirebaseBusLocation document stored in firestore bus_locations collection
+	FirebaseBusLocation struct {
+		BusID     int64     `firestore:"bus_id"`
+		Long      float64   `firestore:"longitude"`
+		Lat       float64   `firestore:"latitude"`
+		Timestamp time.Time `firestore:"timestamp"`
+		Speed     float64   `firestore:"speed"`
+		Heading   float64   `firestore:"heading"`
+	}
+
 	BusLocationQuery struct {
 		Type           string
 		Token          string
 		Experimental   string
 		ExperminetalID string
+		Sandbox        string
+		SandboxToken   string
+		Schedule       string
 	}
 
 	BusLocationMessage struct {
@@ -137,19 +153,48 @@ type (
 		Lat      float64   `json:"lat"`
 		Speed    float64   `json:"speed"`
 		Heading  float64   `json:"heading"`
+
+		// CrowdStatus driver status blended with rider report
+		CrowdStatus     BusStatus `json:"crowdStatus"`
+		CrowdConfidence float64   `json:"crowdConfidence"`
 	}
 	BusInfo struct {
-		ID       uint      `json:"id"`
-		Number   int       `json:"number"`
-		Plate    string    `json:"plate"`
-		Status   BusStatus `json:"status"`
-		Route    Route     `json:"route"`
-		Estimate int       `json:"estimate"`
+		ID           uint      `json:"id"`
+		Number       int       `json:"number"`
+		Plate        string    `json:"plate"`
+		Status       BusStatus `json:"status"`
+		Route        Route     `json:"route"`
+		Estimate     int       `json:"estimate"`
+		NextTerminal string    `json:"nextTerminal"`
+		StopsAway    int       `json:"stopsAway"`
 	}
 
 	// BusInfoResponse BusInfoResponse
 	BusInfoResponse struct {
-		Bus []BusInfo `json:"bus"`
+		Bus     []BusInfo          `json:"bus"`
+		Service RouteServiceStatus `json:"service"`
+		Closure *ClosureInfo       `json:"closure,omitempty"`
+	}
+
+	UpcomingStop struct {
+		ID        uint      `json:"id"`
+		Name      string    `json:"name"`
+		StopsAway int       `json:"stopsAway"`
+		Distance  float64   `json:"distance"`
+		Estimate  int       `json:"estimate"`
+		ArrivalAt time.Time `json:"arrivalAt"`
+	}
+
+	// UpcomingStopResponse UpcomingStopResponse
+	UpcomingStopResponse struct {
+		ID     uint           `json:"id"`
+		Number int            `json:"number"`
+		Plate  string         `json:"plate"`
+		Status BusStatus      `json:"status"`
+		Route  Route          `json:"route"`
+		Long   float64        `json:"long"`
+		Lat    float64        `json:"lat"`
+		Stops  []UpcomingStop `json:"stops"`
 	}
 
 	Connection struct {
@@ -221,6 +266,45 @@ func (b *Bus) ToEditBusResponnse() EditBusResponse {
 	}
 }
 
+func (b *Bus) ToTrackLocationResponse() TrackLocationResponse {
+	return TrackLocationResponse{
+		ID:       b.ID,
+		Number:   b.Number,
+		Plate:    b.Plate,
+		Status:   b.Status,
+		Route:    b.Route,
+		IsActive: b.IsActive,
+	}
+}
+
+func (t *TrackLocationResponse) ToUpcomingStopResponse(stops []UpcomingStop) UpcomingStopResponse {
+	return UpcomingStopResponse{
+		ID:     t.ID,
+		Number: t.Number,
+		Plate:  t.Plate,
+		Status: t.Status,
+		Route:  t.Route,
+		Long:   t.Long,
+		Lat:    t.Lat,
+		Stops:  stops,
+	}
+}
+
+/**
+ * Blend driver status with rider crowd signal by the signal confidence
+ */
+func (t *TrackLocationResponse) BlendCrowd(signal CrowdSignal) {
+	t.CrowdStatus = t.Status
+	t.CrowdConfidence = signal.Confidence
+
+	if signal.Reports == 0 {
+		return
+	}
+
+	level := t.Status.Level()*(1-signal.Confidence) + signal.Level*signal.Confidence
+	t.CrowdStatus = LevelToStatus(level)
+}
+
 func (t *TrackLocationResponse) GetBusSpeed() float64 {
 	if t.Speed <= 0.0 {
 		return DEFAULTBUSSPEED
@@ -0,0 +1,253 @@
+package dto
+
+import (
+	"time"
+	"tracking-server/shared/common"
+)
+
+const (
+	CLOSURE ClosureType = "CLOSURE"
+	DETOUR  ClosureType = "DETOUR"
+
+	// Closure kept in memory before reloaded from database
+	CLOSURECACHETTL = time.Minute
+)
+
+type (
+	ClosureType string
+
+	// Closure terminal of a route not served between start and end time
+	// Detour closure also carry the alternative path bus take meanwhile as encoded polyline
+	Closure struct {
+		ID           uint       `gorm:"primaryKey;autoIncrement"`
+		Route        Route      `gorm:"column:route;index"`
+		Reason       string     `gorm:"column:reason"`
+		ReasonEn     string     `gorm:"column:reason_en"`
+		StartAt      time.Time  `gorm:"column:start_at"`
+		EndAt        time.Time  `gorm:"column:end_at;index"`
+		Detour       string     `gorm:"column:detour"`
+		DetourLength float64    `gorm:"column:detour_length"`
+		CreatedAt    time.Time  `gorm:"column:created_at"`
+		Terminals    []Terminal `gorm:"many2many:closure_terminals"`
+	}
+
+	// ClosureSlice closure in effect on a route
+	ClosureSlice []Closure
+
+	// ClosureInfo reason a terminal is not served, shown to rider
+	ClosureInfo struct {
+		ID      uint        `json:"id"`
+		Type    ClosureType `json:"type"`
+		Reason  string      `json:"reason"`
+		StartAt time.Time   `json:"startAt"`
+		EndAt   time.Time   `json:"endAt"`
+	}
+
+	ClosedTerminal struct {
+		ID   uint   `json:"id"`
+		Name string `json:"name"`
+	}
+
+	// DetourResponse alternative path of a route while the detour in effect
+	DetourResponse struct {
+		ClosureID uint    `json:"closureId"`
+		Reason    string  `json:"reason"`
+		Polyline  string  `json:"polyline"`
+		Length    float64 `json:"length"`
+	}
+
+	// ClosureResponse ClosureResponse
+	ClosureResponse struct {
+		ID        uint             `json:"id"`
+		Type      ClosureType      `json:"type"`
+		Route     Route            `json:"route"`
+		Reason    string           `json:"reason"`
+		ReasonEn  string           `json:"reasonEn"`
+		StartAt   time.Time        `json:"startAt"`
+		EndAt     time.Time        `json:"endAt"`
+		InEffect  bool             `json:"inEffect"`
+		Terminals []ClosedTerminal `json:"terminals"`
+		Detour    *DetourResponse  `json:"detour,omitempty"`
+	}
+
+	// CreateClosureDto CreateClosureDto
+	// Detour without closed terminal only change the path of the route
+	CreateClosureDto struct {
+		Route       Route           `json:"route" validate:"required,route"`
+		Reason      string          `json:"reason" validate:"required"`
+		ReasonEn    string          `json:"reasonEn"`
+		StartAt     time.Time       `json:"startAt" validate:"required"`
+		EndAt       time.Time       `json:"endAt" validate:"required,gtfield=StartAt"`
+		TerminalIDs []uint          `json:"terminalIds" validate:"required_without=Detour,omitempty,unique"`
+		Detour      *ImportShapeDto `json:"detour" validate:"required_without=TerminalIDs,omitempty"`
+	}
+
+	// EditClosureDto EditClosureDto
+	EditClosureDto struct {
+		Reason      string          `json:"reason" validate:"omitempty"`
+		ReasonEn    *string         `json:"reasonEn" validate:"omitempty"`
+		StartAt     *time.Time      `json:"startAt" validate:"omitempty"`
+		EndAt       *time.Time      `json:"endAt" validate:"omitempty"`
+		TerminalIDs *[]uint         `json:"terminalIds" validate:"omitempty,unique"`
+		Detour      *ImportShapeDto `json:"detour" validate:"omitempty"`
+		ClearDetour bool            `json:"clearDetour"`
+	}
+
+	// GetAllClosureQuery GetAllClosureQuery
+	// Active limit the closure to the one in effect or upcoming
+	GetAllClosureQuery struct {
+		Route  Route `query:"route"`
+		Active bool  `query:"active"`
+	}
+
+	// GetAllClosureResponse GetAllClosureResponse
+	GetAllClosureResponse struct {
+		Closures []ClosureResponse `json:"closures"`
+	}
+)
+
+func (c *Closure) Type() ClosureType {
+	if c.Detour != "" {
+		return DETOUR
+	}
+	return CLOSURE
+}
+
+func (c *Closure) InEffect(at time.Time) bool {
+	return !at.Before(c.StartAt) && at.Before(c.EndAt)
+}
+
+func (c *Closure) Localize(locale common.Locale) {
+	c.Reason = common.Localize(locale, c.Reason, c.ReasonEn)
+}
+
+func (c *Closure) ToClosureInfo() ClosureInfo {
+	return ClosureInfo{
+		ID:      c.ID,
+		Type:    c.Type(),
+		Reason:  c.Reason,
+		StartAt: c.StartAt,
+		EndAt:   c.EndAt,
+	}
+}
+
+func (c *Closure) ToDetourResponse() *DetourResponse {
+	if c.Detour == "" {
+		return nil
+	}
+	return &DetourResponse{
+		ClosureID: c.ID,
+		Reason:    c.Reason,
+		Polyline:  c.Detour,
+		Length:    c.DetourLength,
+	}
+}
+
+func (c *Closure) ToClosureResponse(at time.Time) ClosureResponse {
+	terminals := make([]ClosedTerminal, 0, len(c.Terminals))
+	for _, t := range c.Terminals {
+		terminals = append(terminals, ClosedTerminal{ID: t.ID, Name: t.Name})
+	}
+
+	return ClosureResponse{
+		ID:        c.ID,
+		Type:      c.Type(),
+		Route:     c.Route,
+		Reason:    c.Reason,
+		ReasonEn:  c.ReasonEn,
+		StartAt:   c.StartAt,
+		EndAt:     c.EndAt,
+		InEffect:  c.InEffect(at),
+		Terminals: terminals,
+		Detour:    c.ToDetourResponse(),
+	}
+}
+
+func (c *Closure) SetDetour(points []common.Point) {
+	c.Detour = common.EncodePolyline(points)
+	c.DetourLength = common.NewShape(points).Length
+}
+
+func (d *CreateClosureDto) ToClosure() Closure {
+	return Closure{
+		Route:     d.Route,
+		Reason:    d.Reason,
+		ReasonEn:  d.ReasonEn,
+		StartAt:   d.StartAt,
+		EndAt:     d.EndAt,
+		CreatedAt: time.Now(),
+	}
+}
+
+/**
+ * Closure neither closing a terminal nor carrying a detour has no effect
+ */
+func (c *Closure) Empty() bool {
+	return len(c.Terminals) == 0 && c.Detour == ""
+}
+
+func (c *Closure) FillClosureEdit(data EditClosureDto) {
+	if data.Reason != "" {
+		c.Reason = data.Reason
+	}
+
+	if data.ReasonEn != nil {
+		c.ReasonEn = *data.ReasonEn
+	}
+
+	if data.StartAt != nil {
+		c.StartAt = *data.StartAt
+	}
+
+	if data.EndAt != nil {
+		c.EndAt = *data.EndAt
+	}
+
+	if data.ClearDetour {
+		c.Detour = ""
+		c.DetourLength = 0
+	}
+}
+
+func (s ClosureSlice) Localize(locale common.Locale) {
+	for i := range s {
+		s[i].Localize(locale)
+	}
+}
+
+/**
+ * Closure that close the terminal, nil when the terminal is served
+ */
+func (s ClosureSlice) Find(terminalID uint) *Closure {
+	for i := range s {
+		for _, t := range s[i].Terminals {
+			if t.ID == terminalID {
+				return &s[i]
+			}
+		}
+	}
+	return nil
+}
+
+/**
+ * Index of the ordered terminal not served by the route
+ */
+func (s ClosureSlice) ClosedIndex(terminals []Terminal) map[int]bool {
+	res := make(map[int]bool)
+	for i, t := range terminals {
+		if s.Find(t.ID) != nil {
+			res[i] = true
+		}
+	}
+	return res
+}
+
+func (s ClosureSlice) ToDetourResponse() []DetourResponse {
+	res := make([]DetourResponse, 0)
+	for i := range s {
+		if d := s[i].ToDetourResponse(); d != nil {
+			res = append(res, *d)
+		}
+	}
+	return res
+}
@@ -0,0 +1,141 @@
+package dto
+
+import "time"
+
+const (
+	// Status older than this duration without new change no longer counted
+	CROWDMAXINTERVAL = 2 * time.Hour
+
+	CROWDHISTORYDAYS   = 28
+	CROWDPREDICTWINDOW = 60
+	CROWDMINSAMPLE     = 5
+
+	TERMINALSOURCE = "TERMINAL"
+	ROUTESOURCE    = "ROUTE"
+)
+
+type (
+	// CrowdStatusChange every crowd status change reported by driver
+	CrowdStatusChange struct {
+		ID             uint      `gorm:"primaryKey;autoIncrement"`
+		BusID          uint      `gorm:"column:bus_id;index"`
+		Route          Route     `gorm:"column:route"`
+		Status         BusStatus `gorm:"column:status"`
+		PreviousStatus BusStatus `gorm:"column:previous_status"`
+		Long           float64   `gorm:"column:longitude"`
+		Lat            float64   `gorm:"column:latitude"`
+		TerminalID     *uint     `gorm:"column:terminal_id;index"`
+		Timestamp      time.Time `gorm:"column:timestamp;index"`
+	}
+
+	// CrowdShare portion of time, or of sample, of each crowd status
+	CrowdShare struct {
+		Empty    float64 `json:"empty"`
+		Moderate float64 `json:"moderate"`
+		Full     float64 `json:"full"`
+	}
+
+	HourlyCrowd struct {
+		Hour    int        `json:"hour"`
+		Minutes float64    `json:"minutes"`
+		Level   float64    `json:"level"`
+		Share   CrowdShare `json:"share"`
+	}
+
+	RouteCrowdStatistic struct {
+		Route Route         `json:"route"`
+		Hours []HourlyCrowd `json:"hours"`
+	}
+
+	// CrowdStatisticQuery CrowdStatisticQuery
+	CrowdStatisticQuery struct {
+		Route Route `query:"route" validate:"omitempty,route"`
+		Days  int   `query:"days" validate:"omitempty,min=1,max=180"`
+	}
+
+	// GetCrowdStatisticResponse GetCrowdStatisticResponse
+	GetCrowdStatisticResponse struct {
+		Routes []RouteCrowdStatistic `json:"routes"`
+	}
+
+	// PredictCrowdQuery PredictCrowdQuery
+	PredictCrowdQuery struct {
+		At     string `query:"at" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+		Window int    `query:"window" validate:"omitempty,min=15,max=240"`
+		Days   int    `query:"days" validate:"omitempty,min=1,max=180"`
+	}
+
+	// PredictCrowdResponse PredictCrowdResponse
+	PredictCrowdResponse struct {
+		TerminalID uint       `json:"terminalId"`
+		Terminal   string     `json:"terminal"`
+		Route      Route      `json:"route"`
+		From       time.Time  `json:"from"`
+		To         time.Time  `json:"to"`
+		Status     BusStatus  `json:"status"`
+		Level      float64    `json:"level"`
+		Share      CrowdShare `json:"share"`
+		Samples    int        `json:"samples"`
+		Source     string     `json:"source"`
+	}
+)
+
+/**
+ * Crowd level used for averaging, EMPTY is 0 and FULL is 2
+ */
+func (s BusStatus) Level() float64 {
+	switch s {
+	case MODERATE:
+		return 1
+	case FULL:
+		return 2
+	default:
+		return 0
+	}
+}
+
+/**
+ * Nearest crowd status of an average level
+ */
+func LevelToStatus(level float64) BusStatus {
+	switch {
+	case level < 0.5:
+		return EMPTY
+	case level < 1.5:
+		return MODERATE
+	default:
+		return FULL
+	}
+}
+
+/**
+ * Add weight to the share of a status
+ */
+func (c *CrowdShare) Add(status BusStatus, weight float64) {
+	switch status {
+	case MODERATE:
+		c.Moderate += weight
+	case FULL:
+		c.Full += weight
+	default:
+		c.Empty += weight
+	}
+}
+
+/**
+ * Scale share to portion of the total, return the total and average level
+ */
+func (c *CrowdShare) Normalize() (float64, float64) {
+	total := c.Empty + c.Moderate + c.Full
+	if total == 0 {
+		return 0, 0
+	}
+
+	level := (c.Moderate*MODERATE.Level() + c.Full*FULL.Level()) / total
+
+	c.Empty /= total
+	c.Moderate /= total
+	c.Full /= total
+
+	return total, level
+}
@@ -0,0 +1,63 @@
+package dto
+
+import (
+	"time"
+	"tracking-server/shared/config"
+)
+
+const (
+	ARRIVAL   TerminalEventType = "ARRIVAL"
+	DEPARTURE TerminalEventType = "DEPARTURE"
+
+	// Bus leave the geofence only after passing radius multiplied by this factor
+	GEOFENCEEXITFACTOR = 1.5
+
+	DEFAULTEVENTLIMIT = 100
+)
+
+type (
+	TerminalEventType string
+
+	TerminalEvent struct {
+		ID         uint              `gorm:"primaryKey;autoIncrement" json:"id"`
+		BusID      uint              `gorm:"column:bus_id;index" json:"busId"`
+		TerminalID uint              `gorm:"column:terminal_id;index" json:"terminalId"`
+		Route      Route             `gorm:"column:route" json:"route"`
+		Type       TerminalEventType `gorm:"column:type" json:"type"`
+		Timestamp  time.Time         `gorm:"column:timestamp;index" json:"timestamp"`
+		Dwell      float64           `gorm:"column:dwell" json:"dwell"`
+	}
+
+	// GeofenceState terminal a bus currently inside
+	GeofenceState struct {
+		TerminalID uint
+		Route      Route
+		EnteredAt  time.Time
+	}
+
+	// TerminalEventQuery TerminalEventQuery
+	TerminalEventQuery struct {
+		BusID      uint              `query:"busId"`
+		TerminalID uint              `query:"terminalId"`
+		Route      Route             `query:"route" validate:"omitempty,route"`
+		Type       TerminalEventType `query:"type" validate:"omitempty,oneof=ARRIVAL DEPARTURE"`
+		From       string            `query:"from" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+		To         string            `query:"to" validate:"omitempty,datetime=2006-01-02T15:04:05Z07:00"`
+		Limit      int               `query:"limit" validate:"omitempty,min=1,max=1000"`
+	}
+
+	// GetTerminalEventResponse GetTerminalEventResponse
+	GetTerminalEventResponse struct {
+		Events []TerminalEvent `json:"events"`
+	}
+)
+
+/**
+ * Radius in km around terminal where a bus considered at the terminal
+ */
+func GeofenceRadius(env *config.EnvConfig) float64 {
+	if env == nil || env.GeofenceRadius <= 0 {
+		return ARRIVALRADIUS
+	}
+	return env.GeofenceRadius / 1000
+}
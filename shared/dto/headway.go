@@ -0,0 +1,74 @@
+package dto
+
+import "time"
+
+const (
+	NORMAL   HeadwayStatus = "NORMAL"
+	BUNCHING HeadwayStatus = "BUNCHING"
+	GAP      HeadwayStatus = "GAP"
+	RESOLVED HeadwayStatus = "RESOLVED"
+
+	// Alert dropped for subscriber not reading fast enough
+	HEADWAYALERTBUFFER = 16
+)
+
+type (
+	HeadwayStatus string
+
+	// Headway gap between a bus and the bus ahead of it on the same route
+	Headway struct {
+		BusID        uint          `json:"busId"`
+		Number       int           `json:"number"`
+		Plate        string        `json:"plate"`
+		LeaderID     uint          `json:"leaderId"`
+		LeaderNumber int           `json:"leaderNumber"`
+		Distance     float64       `json:"distance"`
+		Minute       float64       `json:"minute"`
+		Status       HeadwayStatus `json:"status"`
+	}
+
+	RouteHeadway struct {
+		Route     Route     `json:"route"`
+		Buses     int       `json:"buses"`
+		Headways  []Headway `json:"headways"`
+		UpdatedAt time.Time `json:"updatedAt"`
+	}
+
+	HeadwayAlert struct {
+		Type         HeadwayStatus `json:"type"`
+		Route        Route         `json:"route"`
+		BusID        uint          `json:"busId"`
+		Number       int           `json:"number"`
+		Plate        string        `json:"plate"`
+		LeaderID     uint          `json:"leaderId"`
+		LeaderNumber int           `json:"leaderNumber"`
+		Distance     float64       `json:"distance"`
+		Minute       float64       `json:"minute"`
+		Timestamp    time.Time     `json:"timestamp"`
+	}
+
+	// GetHeadwayQuery GetHeadwayQuery
+	GetHeadwayQuery struct {
+		Route Route `query:"route" validate:"omitempty,route"`
+	}
+
+	// GetHeadwayResponse GetHeadwayResponse
+	GetHeadwayResponse struct {
+		Routes []RouteHeadway `json:"routes"`
+	}
+)
+
+func (h *Headway) ToHeadwayAlert(route Route, status HeadwayStatus, at time.Time) HeadwayAlert {
+	return HeadwayAlert{
+		Type:         status,
+		Route:        route,
+		BusID:        h.BusID,
+		Number:       h.Number,
+		Plate:        h.Plate,
+		LeaderID:     h.LeaderID,
+		LeaderNumber: h.LeaderNumber,
+		Distance:     h.Distance,
+		Minute:       h.Minute,
+		Timestamp:    at,
+	}
+}
@@ -0,0 +1,218 @@
+package dto
+
+import (
+	"errors"
+	"fmt"
+	"time"
+	"tracking-server/shared/common"
+)
+
+var (
+	ErrDuplicatePlace    = errors.New("place listed more than once")
+	ErrDuplicateRoute    = errors.New("route listed more than once")
+	ErrDuplicateTerminal = errors.New("terminal listed more than once")
+	ErrUnknownRoute      = errors.New("terminal refer to unknown route")
+	ErrUnknownPlace      = errors.New("terminal refer to unknown place")
+)
+
+func init() {
+	common.RegisterErrorTranslation(ErrDuplicatePlace, "tempat tercantum lebih dari sekali")
+	common.RegisterErrorTranslation(ErrDuplicateRoute, "rute tercantum lebih dari sekali")
+	common.RegisterErrorTranslation(ErrDuplicateTerminal, "terminal tercantum lebih dari sekali")
+	common.RegisterErrorTranslation(ErrUnknownRoute, "terminal merujuk rute yang tidak dikenal")
+	common.RegisterErrorTranslation(ErrUnknownPlace, "terminal merujuk tempat yang tidak dikenal")
+}
+
+type (
+	// Network route network kept in a versioned data file
+	Network struct {
+		Version   int               `json:"version" yaml:"version" validate:"required,min=1"`
+		Routes    []NetworkRoute    `json:"routes" yaml:"routes" validate:"dive"`
+		Terminals []NetworkTerminal `json:"terminals" yaml:"terminals" validate:"dive"`
+		Places    []NetworkPlace    `json:"places" yaml:"places" validate:"dive"`
+	}
+
+	NetworkRoute struct {
+		Code        Route          `json:"code" yaml:"code" validate:"required,uppercase,alphanum,max=16"`
+		Name        string         `json:"name" yaml:"name" validate:"required"`
+		Color       string         `json:"color" yaml:"color" validate:"omitempty,hexcolor"`
+		Direction   RouteDirection `json:"direction" yaml:"direction" validate:"omitempty,oneof=CLOCKWISE COUNTERCLOCKWISE"`
+		Active      *bool          `json:"active,omitempty" yaml:"active,omitempty"`
+		Description string         `json:"description,omitempty" yaml:"description,omitempty"`
+
+		// Shape encoded polyline of the road the route drives through
+		Shape string `json:"shape,omitempty" yaml:"shape,omitempty"`
+	}
+
+	NetworkPlace struct {
+		Name     string        `json:"name" yaml:"name" validate:"required"`
+		NameEn   string        `json:"nameEn,omitempty" yaml:"nameEn,omitempty"`
+		Category PlaceCategory `json:"category" yaml:"category" validate:"required,oneof=ACADEMIC WORSHIP TRANSPORT HOUSING HEALTH SPORT FACILITY GATE"`
+		Lat      float64       `json:"lat" yaml:"lat" validate:"required,latitude"`
+		Long     float64       `json:"long" yaml:"long" validate:"required,longitude"`
+		Aliases  []string      `json:"aliases,omitempty" yaml:"aliases,omitempty"`
+	}
+
+	// NetworkTerminal terminal listed in route order, sequence only needed to override it
+	// Places refer to place name around the terminal
+	NetworkTerminal struct {
+		Name     string   `json:"name" yaml:"name" validate:"required"`
+		NameEn   string   `json:"nameEn,omitempty" yaml:"nameEn,omitempty"`
+		Route    Route    `json:"route" yaml:"route" validate:"required"`
+		Sequence int      `json:"sequence,omitempty" yaml:"sequence,omitempty" validate:"omitempty,min=1"`
+		Lat      float64  `json:"lat" yaml:"lat" validate:"required,latitude"`
+		Long     float64  `json:"long" yaml:"long" validate:"required,longitude"`
+		Places   []string `json:"places,omitempty" yaml:"places,omitempty"`
+	}
+
+	// NetworkImport network file version already applied to the database
+	NetworkImport struct {
+		ID         uint      `gorm:"primaryKey;autoIncrement"`
+		Version    int       `gorm:"column:version"`
+		Checksum   string    `gorm:"column:checksum"`
+		Source     string    `gorm:"column:source"`
+		ImportedAt time.Time `gorm:"column:imported_at"`
+	}
+)
+
+/**
+ * Check terminal only refer to route and place in the file and nothing listed twice
+ */
+func (n *Network) Check() error {
+	places := make(map[string]bool, len(n.Places))
+	for _, p := range n.Places {
+		if places[p.Name] {
+			return fmt.Errorf("%w: %s", ErrDuplicatePlace, p.Name)
+		}
+		places[p.Name] = true
+	}
+
+	routes := make(map[Route]bool, len(n.Routes))
+	for _, r := range n.Routes {
+		if routes[r.Code] {
+			return fmt.Errorf("%w: %s", ErrDuplicateRoute, r.Code)
+		}
+		routes[r.Code] = true
+	}
+
+	terminals := make(map[string]bool, len(n.Terminals))
+	for _, t := range n.Terminals {
+		if !routes[t.Route] {
+			return fmt.Errorf("%w: %s on %s", ErrUnknownRoute, t.Name, t.Route)
+		}
+
+		key := string(t.Route) + "/" + t.Name
+		if terminals[key] {
+			return fmt.Errorf("%w: %s", ErrDuplicateTerminal, key)
+		}
+		terminals[key] = true
+
+		for _, p := range t.Places {
+			if !places[p] {
+				return fmt.Errorf("%w: %s to %s", ErrUnknownPlace, key, p)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (n *Network) ToBusRoutes() []BusRoute {
+	res := make([]BusRoute, 0, len(n.Routes))
+	for _, r := range n.Routes {
+		route := BusRoute{
+			Code:        r.Code,
+			Name:        r.Name,
+			Color:       r.Color,
+			Direction:   r.Direction,
+			IsActive:    true,
+			Description: r.Description,
+		}
+		if r.Active != nil {
+			route.IsActive = *r.Active
+		}
+		if route.Direction == "" {
+			route.Direction = CLOCKWISE
+		}
+		res = append(res, route)
+	}
+	return res
+}
+
+/**
+ * Terminal without sequence follow its position among terminal of the same route
+ */
+func (n *Network) ToTerminals() []Terminal {
+	var (
+		res      = make([]Terminal, 0, len(n.Terminals))
+		position = make(map[Route]int)
+	)
+
+	for _, t := range n.Terminals {
+		position[t.Route]++
+
+		terminal := Terminal{
+			Name:     t.Name,
+			NameEn:   t.NameEn,
+			Route:    t.Route,
+			Lat:      t.Lat,
+			Long:     t.Long,
+			Sequence: t.Sequence,
+		}
+		if terminal.Sequence == 0 {
+			terminal.Sequence = position[t.Route]
+		}
+		res = append(res, terminal)
+	}
+	return res
+}
+
+func (n *Network) ToPlaces() []Place {
+	res := make([]Place, 0, len(n.Places))
+	for _, p := range n.Places {
+		res = append(res, Place{
+			Name:     p.Name,
+			NameEn:   p.NameEn,
+			Category: p.Category,
+			Lat:      p.Lat,
+			Long:     p.Long,
+		})
+	}
+	return res
+}
+
+func (p *Place) ToNetworkPlace() NetworkPlace {
+	return NetworkPlace{
+		Name:     p.Name,
+		NameEn:   p.NameEn,
+		Category: p.Category,
+		Lat:      p.Lat,
+		Long:     p.Long,
+		Aliases:  p.AliasNames(),
+	}
+}
+
+func (r *BusRoute) ToNetworkRoute(shape string) NetworkRoute {
+	active := r.IsActive
+	return NetworkRoute{
+		Code:        r.Code,
+		Name:        r.Name,
+		Color:       r.Color,
+		Direction:   r.Direction,
+		Active:      &active,
+		Description: r.Description,
+		Shape:       shape,
+	}
+}
+
+func (t *Terminal) ToNetworkTerminal() NetworkTerminal {
+	return NetworkTerminal{
+		Name:     t.Name,
+		NameEn:   t.NameEn,
+		Route:    t.Route,
+		Sequence: t.Sequence,
+		Lat:      t.Lat,
+		Long:     t.Long,
+		Places:   t.PlaceNames(),
+	}
+}
@@ -1,12 +1,17 @@
 package dto
 
-import "time"
+import (
+	"time"
+	"tracking-server/shared/common"
+)
 
 type (
 	News struct {
 		ID        uint      `gorm:"primaryKey;autoIncrement" json:"id"`
 		Title     string    `gorm:"column:title" json:"title"`
 		Detail    string    `gorm:"column:detail" json:"detail"`
+		TitleEn   string    `gorm:"column:title_en" json:"titleEn,omitempty"`
+		DetailEn  string    `gorm:"column:detail_en" json:"detailEn,omitempty"`
 		CreatedAt time.Time `gorm:"column:createdAt" json:"createdAt"`
 	}
 
@@ -14,8 +19,10 @@ type (
 
 	// CreateNewsDto CreateNewsDto
 	CreateNewsDto struct {
-		Title  string `json:"title" validate:"required"`
-		Detail string `json:"detail" validate:"required"`
+		Title    string `json:"title" validate:"required"`
+		Detail   string `json:"detail" validate:"required"`
+		TitleEn  string `json:"titleEn" validate:"omitempty"`
+		DetailEn string `json:"detailEn" validate:"omitempty"`
 	}
 
 	// CreateNewsResponse CreateNewsResponse
@@ -23,6 +30,8 @@ type (
 		ID        uint   `json:"id"`
 		Title     string `json:"title"`
 		Detail    string `json:"detail"`
+		TitleEn   string `json:"titleEn,omitempty"`
+		DetailEn  string `json:"detailEn,omitempty"`
 		CreatedAt string `json:"createdAt"`
 	}
 
@@ -33,8 +42,10 @@ type (
 
 	// EditNewsDto EditNewsDto
 	EditNewsDto struct {
-		Title  string `json:"title" validate:"omitempty"`
-		Detail string `json:"detail" validate:"omitempty"`
+		Title    string `json:"title" validate:"omitempty"`
+		Detail   string `json:"detail" validate:"omitempty"`
+		TitleEn  string `json:"titleEn" validate:"omitempty"`
+		DetailEn string `json:"detailEn" validate:"omitempty"`
 	}
 )
 
@@ -43,6 +54,8 @@ func (n *News) ToCreateNewsResponse() CreateNewsResponse {
 		ID:        n.ID,
 		Title:     n.Title,
 		Detail:    n.Detail,
+		TitleEn:   n.TitleEn,
+		DetailEn:  n.DetailEn,
 		CreatedAt: n.CreatedAt.String(),
 	}
 }
@@ -61,4 +74,26 @@ func (n *News) FillNewsEdit(data EditNewsDto) {
 	if data.Detail != "" {
 		n.Detail = data.Detail
 	}
+
+	if data.TitleEn != "" {
+		n.TitleEn = data.TitleEn
+	}
+
+	if data.DetailEn != "" {
+		n.DetailEn = data.DetailEn
+	}
+}
+
+/**
+ * Replace title and detail with the locale text, only for response
+ */
+func (n *News) Localize(locale common.Locale) {
+	n.Title = common.Localize(locale, n.Title, n.TitleEn)
+	n.Detail = common.Localize(locale, n.Detail, n.DetailEn)
+}
+
+func (n NewsSlice) Localize(locale common.Locale) {
+	for i := range n {
+		n[i].Localize(locale)
+	}
 }
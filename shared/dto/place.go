@@ -0,0 +1,117 @@
+package dto
+
+import "tracking-server/shared/common"
+
+const (
+	// Place category
+	ACADEMIC  PlaceCategory = "ACADEMIC"
+	WORSHIP   PlaceCategory = "WORSHIP"
+	TRANSPORT PlaceCategory = "TRANSPORT"
+	HOUSING   PlaceCategory = "HOUSING"
+	HEALTH    PlaceCategory = "HEALTH"
+	SPORT     PlaceCategory = "SPORT"
+	FACILITY  PlaceCategory = "FACILITY"
+	GATE      PlaceCategory = "GATE"
+
+	DEFAULTPLACELIMIT = 10
+)
+
+type (
+	PlaceCategory string
+
+	// Place point of interest rider travel to, served by terminal around it
+	Place struct {
+		ID        uint          `gorm:"primaryKey;autoIncrement"`
+		Name      string        `gorm:"column:name;unique"`
+		NameEn    string        `gorm:"column:name_en"`
+		Category  PlaceCategory `gorm:"column:category"`
+		Lat       float64       `gorm:"column:latitude"`
+		Long      float64       `gorm:"column:longitude"`
+		Aliases   []PlaceAlias  `gorm:"foreignKey:PlaceID"`
+		Terminals []Terminal    `gorm:"many2many:terminal_places"`
+	}
+
+	// PlaceAlias other name rider search a place with, e.g. perpus
+	PlaceAlias struct {
+		ID      uint   `gorm:"primaryKey;autoIncrement"`
+		PlaceID uint   `gorm:"column:place_id;index"`
+		Alias   string `gorm:"column:alias"`
+	}
+
+	// SearchPlaceQuery SearchPlaceQuery
+	// Lat and long of the rider pick the boarding terminal of each route
+	SearchPlaceQuery struct {
+		Q     string  `query:"q" validate:"required,min=2"`
+		Lat   float64 `query:"lat" validate:"required_with=Long,omitempty,latitude"`
+		Long  float64 `query:"long" validate:"required_with=Lat,omitempty,longitude"`
+		Limit int     `query:"limit" validate:"omitempty,min=1,max=50"`
+	}
+
+	PlaceTerminal struct {
+		ID         uint    `json:"id"`
+		Name       string  `json:"name"`
+		Distance   float64 `json:"distance"`
+		WalkMinute int     `json:"walkMinute"`
+	}
+
+	// PlaceRoute terminal of a route to board from the rider and to alight at the place
+	PlaceRoute struct {
+		Route     Route          `json:"route"`
+		Boarding  *PlaceTerminal `json:"boarding,omitempty"`
+		Alighting PlaceTerminal  `json:"alighting"`
+	}
+
+	PlaceResponse struct {
+		ID       uint          `json:"id"`
+		Name     string        `json:"name"`
+		Category PlaceCategory `json:"category"`
+		Lat      float64       `json:"lat"`
+		Long     float64       `json:"long"`
+		Aliases  []string      `json:"aliases"`
+		Routes   []PlaceRoute  `json:"routes"`
+	}
+
+	// SearchPlaceResponse SearchPlaceResponse
+	SearchPlaceResponse struct {
+		Places []PlaceResponse `json:"places"`
+	}
+)
+
+/**
+ * Replace place and terminal name with the locale name, only for response
+ */
+func (p *Place) Localize(locale common.Locale) {
+	p.Name = common.Localize(locale, p.Name, p.NameEn)
+	for i := range p.Terminals {
+		p.Terminals[i].Name = common.Localize(locale, p.Terminals[i].Name, p.Terminals[i].NameEn)
+	}
+}
+
+func (p *Place) AliasNames() []string {
+	res := make([]string, 0, len(p.Aliases))
+	for _, a := range p.Aliases {
+		res = append(res, a.Alias)
+	}
+	return res
+}
+
+func (p *Place) ToPlaceResponse(routes []PlaceRoute) PlaceResponse {
+	return PlaceResponse{
+		ID:       p.ID,
+		Name:     p.Name,
+		Category: p.Category,
+		Lat:      p.Lat,
+		Long:     p.Long,
+		Aliases:  p.AliasNames(),
+		Routes:   routes,
+	}
+}
+
+func (t *Terminal) ToPlaceTerminal(distance float64) PlaceTerminal {
+	return PlaceTerminal{
+		ID:         t.ID,
+		Name:       t.Name,
+		Distance:   distance,
+		WalkMinute: WalkMinute(distance),
+	}
+}
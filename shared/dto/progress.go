@@ -0,0 +1,28 @@
+package dto
+
+import "time"
+
+const (
+	// Bus considered at terminal when inside this radius in km, used when geofence radius not configured
+	ARRIVALRADIUS = 0.03
+
+	// Number of segment ahead of last visited terminal a bus can be projected to
+	PROGRESSWINDOW = 3
+
+	// Progress reset when bus not reporting for this duration or too far from the route in km
+	PROGRESSEXPIRY      = 10 * time.Minute
+	PROGRESSMAXDISTANCE = 0.2
+)
+
+type (
+	// BusProgress position of a bus along its route
+	BusProgress struct {
+		BusID       uint
+		Route       Route
+		LastVisited int
+		Segment     int
+		Offset      float64
+		AtTerminal  bool
+		UpdatedAt   time.Time
+	}
+)
@@ -0,0 +1,125 @@
+package dto
+
+import (
+	"math"
+	"time"
+)
+
+const (
+	// Report type
+	CROWDREPORT  ReportType = "CROWD"
+	SKIPPEDSTOP  ReportType = "SKIPPED_STOP"
+	AIRCONBROKEN ReportType = "AC_BROKEN"
+	OTHERREPORT  ReportType = "OTHER"
+
+	// Review status
+	PENDING   ReviewStatus = "PENDING"
+	REVIEWED  ReviewStatus = "REVIEWED"
+	DISMISSED ReviewStatus = "DISMISSED"
+
+	// Device can submit at most REPORTLIMIT report every REPORTLIMITWINDOW
+	// Client ip shared by rider on the same network so allowed more
+	REPORTLIMIT       = 5
+	REPORTIPLIMIT     = 30
+	REPORTLIMITWINDOW = 10 * time.Minute
+
+	// Crowd report older than the window ignored, weight halved every half life
+	REPORTSIGNALWINDOW = 15 * time.Minute
+	REPORTHALFLIFE     = 5 * time.Minute
+
+	// Weight of the driver status against rider report, two fresh report reach half confidence
+	REPORTCONFIDENCEPRIOR = 2.0
+
+	DEFAULTREVIEWLIMIT = 50
+)
+
+type (
+	ReportType   string
+	ReviewStatus string
+
+	RiderReport struct {
+		ID         uint         `gorm:"primaryKey;autoIncrement" json:"id"`
+		BusID      uint         `gorm:"column:bus_id;index" json:"busId"`
+		DeviceID   string       `gorm:"column:device_id;index" json:"deviceId"`
+		ClientIP   string       `gorm:"column:client_ip" json:"-"`
+		Type       ReportType   `gorm:"column:type" json:"type"`
+		Status     BusStatus    `gorm:"column:status" json:"status,omitempty"`
+		TerminalID *uint        `gorm:"column:terminal_id" json:"terminalId,omitempty"`
+		Message    string       `gorm:"column:message" json:"message,omitempty"`
+		Review     ReviewStatus `gorm:"column:review;index" json:"review"`
+		ReviewNote string       `gorm:"column:review_note" json:"reviewNote,omitempty"`
+		ReviewedAt *time.Time   `gorm:"column:reviewed_at" json:"reviewedAt,omitempty"`
+		CreatedAt  time.Time    `gorm:"column:created_at;index" json:"createdAt"`
+	}
+
+	// CrowdSignal crowd level aggregated from recent rider report
+	CrowdSignal struct {
+		Level      float64
+		Confidence float64
+		Reports    int
+	}
+
+	// CreateReportDto CreateReportDto
+	CreateReportDto struct {
+		BusID      uint       `json:"busId" validate:"required"`
+		DeviceID   string     `json:"deviceId" validate:"required,max=64"`
+		Type       ReportType `json:"type" validate:"required,oneof=CROWD SKIPPED_STOP AC_BROKEN OTHER"`
+		Status     BusStatus  `json:"status" validate:"required_if=Type CROWD,omitempty,oneof=EMPTY MODERATE FULL"`
+		TerminalID uint       `json:"terminalId" validate:"required_if=Type SKIPPED_STOP"`
+		Message    string     `json:"message" validate:"required_if=Type OTHER,max=280"`
+	}
+
+	// CreateReportResponse CreateReportResponse
+	CreateReportResponse struct {
+		ID        uint   `json:"id"`
+		CreatedAt string `json:"createdAt"`
+	}
+
+	// ReviewQueueQuery ReviewQueueQuery
+	ReviewQueueQuery struct {
+		Review ReviewStatus `query:"review" validate:"omitempty,oneof=PENDING REVIEWED DISMISSED"`
+		BusID  uint         `query:"busId"`
+		Limit  int          `query:"limit" validate:"omitempty,min=1,max=500"`
+	}
+
+	// ReviewReportDto ReviewReportDto
+	ReviewReportDto struct {
+		Review ReviewStatus `json:"review" validate:"required,oneof=REVIEWED DISMISSED"`
+		Note   string       `json:"note" validate:"max=280"`
+	}
+
+	// GetReviewQueueResponse GetReviewQueueResponse
+	GetReviewQueueResponse struct {
+		Reports []RiderReport `json:"reports"`
+	}
+)
+
+func (d *CreateReportDto) ToRiderReport(at time.Time) RiderReport {
+	report := RiderReport{
+		BusID:     d.BusID,
+		DeviceID:  d.DeviceID,
+		Type:      d.Type,
+		Status:    d.Status,
+		Message:   d.Message,
+		Review:    PENDING,
+		CreatedAt: at,
+	}
+	if d.TerminalID != 0 {
+		report.TerminalID = &d.TerminalID
+	}
+	return report
+}
+
+func (r *RiderReport) ToCreateReportResponse() CreateReportResponse {
+	return CreateReportResponse{
+		ID:        r.ID,
+		CreatedAt: r.CreatedAt.String(),
+	}
+}
+
+/**
+ * Weight of a report decayed by its age
+ */
+func (r *RiderReport) Weight(at time.Time) float64 {
+	return math.Pow(0.5, at.Sub(r.CreatedAt).Minutes()/REPORTHALFLIFE.Minutes())
+}
@@ -0,0 +1,91 @@
+package dto
+
+import "time"
+
+const (
+	CLOCKWISE        RouteDirection = "CLOCKWISE"
+	COUNTERCLOCKWISE RouteDirection = "COUNTERCLOCKWISE"
+)
+
+type (
+	RouteDirection string
+
+	// BusRoute route detail, code referenced by bus and terminal route
+	BusRoute struct {
+		ID          uint           `gorm:"primaryKey;autoIncrement" json:"id"`
+		Code        Route          `gorm:"column:code;unique" json:"code"`
+		Name        string         `gorm:"column:name" json:"name"`
+		Color       string         `gorm:"column:color" json:"color"`
+		Direction   RouteDirection `gorm:"column:direction" json:"direction"`
+		IsActive    bool           `gorm:"column:is_active" json:"isActive"`
+		Description string         `gorm:"column:description" json:"description"`
+		CreatedAt   time.Time      `gorm:"column:created_at" json:"createdAt"`
+	}
+
+	// CreateRouteDto CreateRouteDto
+	CreateRouteDto struct {
+		Code        Route          `json:"code" validate:"required,uppercase,alphanum,max=16"`
+		Name        string         `json:"name" validate:"required"`
+		Color       string         `json:"color" validate:"required,hexcolor"`
+		Direction   RouteDirection `json:"direction" validate:"required,oneof=CLOCKWISE COUNTERCLOCKWISE"`
+		IsActive    bool           `json:"isActive"`
+		Description string         `json:"description"`
+	}
+
+	// EditRouteDto EditRouteDto
+	EditRouteDto struct {
+		Name        string         `json:"name" validate:"omitempty"`
+		Color       string         `json:"color" validate:"omitempty,hexcolor"`
+		Direction   RouteDirection `json:"direction" validate:"omitempty,oneof=CLOCKWISE COUNTERCLOCKWISE"`
+		IsActive    *bool          `json:"isActive" validate:"omitempty"`
+		Description *string        `json:"description" validate:"omitempty"`
+	}
+
+	// GetAllRouteQuery GetAllRouteQuery
+	GetAllRouteQuery struct {
+		Active bool `query:"active"`
+	}
+
+	// GetAllRouteResponse GetAllRouteResponse
+	GetAllRouteResponse struct {
+		Routes []BusRoute `json:"routes"`
+	}
+)
+
+func (BusRoute) TableName() string {
+	return "routes"
+}
+
+func (d *CreateRouteDto) ToBusRoute() BusRoute {
+	return BusRoute{
+		Code:        d.Code,
+		Name:        d.Name,
+		Color:       d.Color,
+		Direction:   d.Direction,
+		IsActive:    d.IsActive,
+		Description: d.Description,
+		CreatedAt:   time.Now(),
+	}
+}
+
+func (r *BusRoute) FillRouteEdit(data EditRouteDto) {
+	if data.Name != "" {
+		r.Name = data.Name
+	}
+
+	if data.Color != "" {
+		r.Color = data.Color
+	}
+
+	if data.Direction != "" {
+		r.Direction = data.Direction
+	}
+
+	if data.IsActive != nil {
+		r.IsActive = *data.IsActive
+	}
+
+	if data.Description != nil {
+		r.Description = *data.Description
+	}
+}
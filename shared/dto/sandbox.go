@@ -0,0 +1,73 @@
+package dto
+
+import "time"
+
+type (
+	Sandbox struct {
+		ID        uint      `gorm:"primaryKey;autoIncrement"`
+		Name      string    `gorm:"column:name;unique"`
+		Token     string    `gorm:"column:token"`
+		CreatedAt time.Time `gorm:"column:created_at"`
+	}
+
+	// SandboxBus link experimental bus in a sandbox to real bus metadata
+	SandboxBus struct {
+		ID             uint   `gorm:"primaryKey;autoIncrement"`
+		SandboxID      uint   `gorm:"column:sandbox_id;uniqueIndex:sandbox_experimental_pair"`
+		ExperimentalID string `gorm:"column:experimental_id;uniqueIndex:sandbox_experimental_pair"`
+		BusID          uint   `gorm:"column:bus_id"`
+		Bus            Bus    `gorm:"foreignKey:BusID"`
+	}
+
+	// SandboxFleet latest location for each experimental bus in a sandbox
+	SandboxFleet map[string]BusLocationMessage
+
+	// CreateSandboxDto CreateSandboxDto
+	CreateSandboxDto struct {
+		Name string `json:"name" validate:"required,alphanum"`
+	}
+
+	// CreateSandboxResponse CreateSandboxResponse
+	CreateSandboxResponse struct {
+		ID        uint   `json:"id"`
+		Name      string `json:"name"`
+		Token     string `json:"token"`
+		CreatedAt string `json:"createdAt"`
+	}
+
+	// LinkSandboxBusDto LinkSandboxBusDto
+	LinkSandboxBusDto struct {
+		ExperimentalID string `json:"experimentalId" validate:"required"`
+		BusID          uint   `json:"busId" validate:"required"`
+	}
+
+	// SandboxBusResponse SandboxBusResponse
+	SandboxBusResponse struct {
+		ExperimentalID string    `json:"experimentalId"`
+		BusID          uint      `json:"busId"`
+		Number         int       `json:"number"`
+		Plate          string    `json:"plate"`
+		Route          Route     `json:"route"`
+		Status         BusStatus `json:"status"`
+	}
+)
+
+func (s *Sandbox) ToCreateSandboxResponse(token string) CreateSandboxResponse {
+	return CreateSandboxResponse{
+		ID:        s.ID,
+		Name:      s.Name,
+		Token:     token,
+		CreatedAt: s.CreatedAt.String(),
+	}
+}
+
+func (s *SandboxBus) ToSandboxBusResponse() SandboxBusResponse {
+	return SandboxBusResponse{
+		ExperimentalID: s.ExperimentalID,
+		BusID:          s.BusID,
+		Number:         s.Bus.Number,
+		Plate:          s.Bus.Plate,
+		Route:          s.Bus.Route,
+		Status:         s.Bus.Status,
+	}
+}
@@ -0,0 +1,127 @@
+package dto
+
+import "time"
+
+const (
+	// Day type
+	WEEKDAY  DayType = "WEEKDAY"
+	SATURDAY DayType = "SATURDAY"
+	SUNDAY   DayType = "SUNDAY"
+	BREAK    DayType = "BREAK"
+
+	// Service exception type
+	HOLIDAY       ExceptionType = "HOLIDAY"
+	SEMESTERBREAK ExceptionType = "BREAK"
+
+	DATEFORMAT  = "2006-01-02"
+	CLOCKFORMAT = "15:04"
+
+	// Number of day searched for the next operating window
+	SCHEDULELOOKAHEAD = 31
+)
+
+type (
+	DayType       string
+	ExceptionType string
+
+	// OperatingWindow service hour of a route on a day type, in WIB
+	OperatingWindow struct {
+		ID      uint    `gorm:"primaryKey;autoIncrement" json:"id"`
+		Route   Route   `gorm:"column:route" json:"route"`
+		DayType DayType `gorm:"column:day_type" json:"dayType"`
+		Start   string  `gorm:"column:start_time" json:"start"`
+		End     string  `gorm:"column:end_time" json:"end"`
+	}
+
+	// ServiceException date range without service or running on semester break hour
+	// Empty route applies to every route
+	ServiceException struct {
+		ID          uint          `gorm:"primaryKey;autoIncrement" json:"id"`
+		Route       Route         `gorm:"column:route" json:"route"`
+		Type        ExceptionType `gorm:"column:type" json:"type"`
+		StartDate   string        `gorm:"column:start_date" json:"startDate"`
+		EndDate     string        `gorm:"column:end_date" json:"endDate"`
+		Description string        `gorm:"column:description" json:"description"`
+	}
+
+	RouteServiceStatus struct {
+		Route     Route      `json:"route"`
+		InService bool       `json:"inService"`
+		Scheduled bool       `json:"scheduled"`
+		DayType   DayType    `json:"dayType,omitempty"`
+		Reason    string     `json:"reason,omitempty"`
+		Until     *time.Time `json:"until,omitempty"`
+		NextStart *time.Time `json:"nextStart,omitempty"`
+	}
+
+	// CreateOperatingWindowDto CreateOperatingWindowDto
+	CreateOperatingWindowDto struct {
+		Route   Route   `json:"route" validate:"required,route"`
+		DayType DayType `json:"dayType" validate:"required,oneof=WEEKDAY SATURDAY SUNDAY BREAK"`
+		Start   string  `json:"start" validate:"required,datetime=15:04"`
+		End     string  `json:"end" validate:"required,datetime=15:04"`
+	}
+
+	// CreateServiceExceptionDto CreateServiceExceptionDto
+	CreateServiceExceptionDto struct {
+		Route       Route         `json:"route" validate:"omitempty,route"`
+		Type        ExceptionType `json:"type" validate:"required,oneof=HOLIDAY BREAK"`
+		StartDate   string        `json:"startDate" validate:"required,datetime=2006-01-02"`
+		EndDate     string        `json:"endDate" validate:"required,datetime=2006-01-02"`
+		Description string        `json:"description"`
+	}
+
+	// GetScheduleResponse GetScheduleResponse
+	GetScheduleResponse struct {
+		Windows    []OperatingWindow  `json:"windows"`
+		Exceptions []ServiceException `json:"exceptions"`
+	}
+
+	// GetServiceStatusResponse GetServiceStatusResponse
+	GetServiceStatusResponse struct {
+		Routes []RouteServiceStatus `json:"routes"`
+	}
+
+	// StreamBusLocationResponse bus location along with route service status
+	StreamBusLocationResponse struct {
+		Bus     []TrackLocationResponse `json:"bus"`
+		Service []RouteServiceStatus    `json:"service"`
+	}
+)
+
+func (d *CreateOperatingWindowDto) ToOperatingWindow() OperatingWindow {
+	return OperatingWindow{
+		Route:   d.Route,
+		DayType: d.DayType,
+		Start:   NormalizeClock(d.Start),
+		End:     NormalizeClock(d.End),
+	}
+}
+
+/**
+ * Zero pad clock like 9:00 into 09:00 so window time compare correctly as string
+ */
+func NormalizeClock(value string) string {
+	t, err := time.Parse(CLOCKFORMAT, value)
+	if err != nil {
+		return value
+	}
+	return t.Format(CLOCKFORMAT)
+}
+
+func (d *CreateServiceExceptionDto) ToServiceException() ServiceException {
+	return ServiceException{
+		Route:       d.Route,
+		Type:        d.Type,
+		StartDate:   d.StartDate,
+		EndDate:     d.EndDate,
+		Description: d.Description,
+	}
+}
+
+/**
+ * Check whether the exception applies to the route on the date
+ */
+func (e *ServiceException) Covers(route Route, date string) bool {
+	return (e.Route == "" || e.Route == route) && e.StartDate <= date && date <= e.EndDate
+}
@@ -0,0 +1,88 @@
+package dto
+
+import (
+	"time"
+	"tracking-server/shared/common"
+)
+
+const (
+	// Search result type
+	TERMINALRESULT SearchType = "terminal"
+	PLACERESULT    SearchType = "place"
+	NEWSRESULT     SearchType = "news"
+
+	DEFAULTSEARCHLIMIT = 20
+
+	// Index rebuilt at least this often even without data change
+	SEARCHINDEXTTL = 10 * time.Minute
+
+	// Score of match on related text, e.g. place around a terminal
+	SEARCHRELATEDWEIGHT = 0.8
+
+	// Score added to result right at the rider, fading out at SEARCHNEARBYRADIUS km
+	SEARCHNEARBYBONUS  = 15.0
+	SEARCHNEARBYRADIUS = 2.0
+)
+
+type (
+	SearchType string
+
+	// SearchDocument entry of the in-memory search index
+	SearchDocument struct {
+		Type       SearchType
+		ID         uint
+		Title      string
+		TitleEn    string
+		Subtitle   string
+		SubtitleEn string
+		Route      Route
+		Lat        float64
+		Long       float64
+		Located    bool
+
+		// Keys normalized name and alias, Related normalized text matched with lower weight
+		Keys    []string
+		Related []string
+	}
+
+	// SearchQuery SearchQuery
+	// Lat and long of the rider rank nearby result higher
+	SearchQuery struct {
+		Q     string     `query:"q" validate:"required"`
+		Type  SearchType `query:"type" validate:"omitempty,oneof=terminal place news"`
+		Lat   float64    `query:"lat" validate:"required_with=Long,omitempty,latitude"`
+		Long  float64    `query:"long" validate:"required_with=Lat,omitempty,longitude"`
+		Limit int        `query:"limit" validate:"omitempty,min=1,max=50"`
+	}
+
+	SearchResult struct {
+		Type     SearchType `json:"type"`
+		ID       uint       `json:"id"`
+		Title    string     `json:"title"`
+		Subtitle string     `json:"subtitle"`
+		Route    Route      `json:"route,omitempty"`
+		Lat      float64    `json:"lat,omitempty"`
+		Long     float64    `json:"long,omitempty"`
+		Distance *float64   `json:"distance,omitempty"`
+		Score    float64    `json:"score"`
+	}
+
+	// SearchResponse SearchResponse
+	SearchResponse struct {
+		Results []SearchResult `json:"results"`
+	}
+)
+
+func (d *SearchDocument) ToSearchResult(score float64, distance *float64, locale common.Locale) SearchResult {
+	return SearchResult{
+		Type:     d.Type,
+		ID:       d.ID,
+		Title:    common.Localize(locale, d.Title, d.TitleEn),
+		Subtitle: common.Localize(locale, d.Subtitle, d.SubtitleEn),
+		Route:    d.Route,
+		Lat:      d.Lat,
+		Long:     d.Long,
+		Distance: distance,
+		Score:    score,
+	}
+}
@@ -0,0 +1,211 @@
+package dto
+
+import (
+	"encoding/json"
+	"errors"
+	"tracking-server/shared/common"
+)
+
+const (
+	POLYLINE ShapeFormat = "polyline"
+	GEOJSON  ShapeFormat = "geojson"
+
+	// Minimum point of a shape to draw a line
+	SHAPEMINPOINT = 2
+)
+
+var ErrShapeTooShort = errors.New("shape must have at least two point")
+
+func init() {
+	common.RegisterErrorTranslation(ErrShapeTooShort, "bentuk jalur minimal memiliki dua titik")
+}
+
+type (
+	ShapeFormat string
+
+	// ShapePoint coordinate of a route shape with precomputed distance along the shape
+	ShapePoint struct {
+		ID       uint    `gorm:"primaryKey;autoIncrement"`
+		Route    Route   `gorm:"column:route;index"`
+		Sequence int     `gorm:"column:sequence"`
+		Lat      float64 `gorm:"column:latitude"`
+		Long     float64 `gorm:"column:longitude"`
+		Distance float64 `gorm:"column:distance"`
+	}
+
+	// ShapePointSlice point of a route shape ordered by sequence
+	ShapePointSlice []ShapePoint
+
+	// GeoJSONGeometry GeoJSONGeometry
+	GeoJSONGeometry struct {
+		Type        string          `json:"type"`
+		Coordinates json.RawMessage `json:"coordinates" swaggertype:"array,number"`
+	}
+
+	// GeoJSONFeature GeoJSONFeature
+	GeoJSONFeature struct {
+		Type       string                 `json:"type"`
+		Properties map[string]interface{} `json:"properties"`
+		Geometry   *GeoJSONGeometry       `json:"geometry"`
+	}
+
+	// GeoJSONObject any of geometry, feature or feature collection
+	GeoJSONObject struct {
+		Type        string           `json:"type"`
+		Coordinates json.RawMessage  `json:"coordinates,omitempty" swaggertype:"array,number"`
+		Geometry    *GeoJSONGeometry `json:"geometry,omitempty"`
+		Features    []GeoJSONFeature `json:"features,omitempty"`
+	}
+
+	// ImportShapeDto ImportShapeDto
+	// Either encoded polyline or GeoJSON LineString geometry, feature or feature collection
+	ImportShapeDto struct {
+		Polyline string         `json:"polyline" validate:"required_without=GeoJSON"`
+		GeoJSON  *GeoJSONObject `json:"geojson" validate:"required_without=Polyline"`
+	}
+
+	// GetRouteShapeQuery GetRouteShapeQuery
+	GetRouteShapeQuery struct {
+		Format ShapeFormat `query:"format" validate:"omitempty,oneof=polyline geojson"`
+	}
+
+	// GetRouteShapeResponse GetRouteShapeResponse
+	GetRouteShapeResponse struct {
+		Route    Route            `json:"route"`
+		Points   int              `json:"points"`
+		Length   float64          `json:"length"`
+		Polyline string           `json:"polyline,omitempty"`
+		GeoJSON  *GeoJSONFeature  `json:"geojson,omitempty"`
+		Detours  []DetourResponse `json:"detours,omitempty"`
+	}
+)
+
+/**
+ * Extract LineString coordinate from GeoJSON
+ * * feature collection use the first LineString feature
+ */
+func (g *GeoJSONObject) ToPoints() ([]common.Point, bool) {
+	switch g.Type {
+	case "LineString":
+		return lineStringToPoints(g.Coordinates)
+	case "Feature":
+		if g.Geometry != nil && g.Geometry.Type == "LineString" {
+			return lineStringToPoints(g.Geometry.Coordinates)
+		}
+	case "FeatureCollection":
+		for _, f := range g.Features {
+			if f.Geometry != nil && f.Geometry.Type == "LineString" {
+				return lineStringToPoints(f.Geometry.Coordinates)
+			}
+		}
+	}
+	return nil, false
+}
+
+/**
+ * Decode shape point from encoded polyline or GeoJSON
+ * Shape must have enough point to draw a line and every coordinate in range
+ */
+func (d *ImportShapeDto) ToPoints() ([]common.Point, error) {
+	var (
+		points []common.Point
+		err    error
+	)
+
+	if d.Polyline != "" {
+		points, err = common.DecodePolyline(d.Polyline)
+		if err != nil {
+			return nil, err
+		}
+	} else if d.GeoJSON != nil {
+		var ok bool
+		points, ok = d.GeoJSON.ToPoints()
+		if !ok {
+			return nil, errors.New("geojson must contain a LineString")
+		}
+	}
+
+	if len(points) < SHAPEMINPOINT {
+		return nil, ErrShapeTooShort
+	}
+	for _, p := range points {
+		if p.Lat < -90 || p.Lat > 90 || p.Long < -180 || p.Long > 180 {
+			return nil, errors.New("shape coordinate out of range")
+		}
+	}
+
+	return points, nil
+}
+
+/**
+ * GeoJSON position is ordered longitude then latitude
+ */
+func lineStringToPoints(raw json.RawMessage) ([]common.Point, bool) {
+	positions := [][]float64{}
+	if err := json.Unmarshal(raw, &positions); err != nil {
+		return nil, false
+	}
+
+	points := make([]common.Point, 0, len(positions))
+	for _, p := range positions {
+		if len(p) < 2 {
+			return nil, false
+		}
+		points = append(points, common.Point{Lat: p[1], Long: p[0]})
+	}
+	return points, true
+}
+
+func NewShapePoints(route Route, shape common.Shape) []ShapePoint {
+	res := make([]ShapePoint, 0, len(shape.Points))
+	for i, p := range shape.Points {
+		res = append(res, ShapePoint{
+			Route:    route,
+			Sequence: i + 1,
+			Lat:      p.Lat,
+			Long:     p.Long,
+			Distance: shape.Distances[i],
+		})
+	}
+	return res
+}
+
+func (s ShapePointSlice) ToShape() common.Shape {
+	shape := common.Shape{
+		Points:    make([]common.Point, 0, len(s)),
+		Distances: make([]float64, 0, len(s)),
+	}
+
+	for _, p := range s {
+		shape.Points = append(shape.Points, common.Point{Lat: p.Lat, Long: p.Long})
+		shape.Distances = append(shape.Distances, p.Distance)
+	}
+
+	if len(s) > 0 {
+		shape.Length = s[len(s)-1].Distance
+	}
+
+	return shape
+}
+
+func ToGeoJSONFeature(route Route, shape common.Shape) *GeoJSONFeature {
+	positions := make([][]float64, 0, len(shape.Points))
+	for _, p := range shape.Points {
+		positions = append(positions, []float64{p.Long, p.Lat})
+	}
+
+	coordinates, _ := json.Marshal(positions)
+
+	return &GeoJSONFeature{
+		Type: "Feature",
+		Properties: map[string]interface{}{
+			"route":     route,
+			"length":    shape.Length,
+			"distances": shape.Distances,
+		},
+		Geometry: &GeoJSONGeometry{
+			Type:        "LineString",
+			Coordinates: coordinates,
+		},
+	}
+}
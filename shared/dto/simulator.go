@@ -0,0 +1,55 @@
+package dto
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	// Simulated bus id start from this offset to avoid collision with real bus
+	SIMULATEDBUSIDOFFSET = 900000
+
+	SIMULATORTICK = 1 * time.Second
+)
+
+type (
+	SimulatedBus struct {
+		ID         uint
+		Number     int
+		Plate      string
+		Status     BusStatus
+		Route      Route
+		Long       float64
+		Lat        float64
+		Speed      float64
+		Heading    float64
+		Segment    int
+		Travelled  float64
+		DwellUntil time.Time
+	}
+)
+
+func NewSimulatedBus(id uint, number int, route Route) *SimulatedBus {
+	return &SimulatedBus{
+		ID:     SIMULATEDBUSIDOFFSET + id,
+		Number: number,
+		Plate:  fmt.Sprintf("SIM %s %d", route, number),
+		Status: EMPTY,
+		Route:  route,
+	}
+}
+
+func (s *SimulatedBus) ToTrackLocationResponse() TrackLocationResponse {
+	return TrackLocationResponse{
+		ID:       s.ID,
+		Number:   s.Number,
+		Plate:    s.Plate,
+		Status:   s.Status,
+		Route:    s.Route,
+		IsActive: true,
+		Long:     s.Long,
+		Lat:      s.Lat,
+		Speed:    s.Speed,
+		Heading:  s.Heading,
+	}
+}
@@ -0,0 +1,142 @@
+package dto
+
+import "tracking-server/shared/common"
+
+type (
+	// Station terminal sharing the same name on different route, rider see one stop
+	// Coordinate is the centroid of the route stop
+	Station struct {
+		ID        uint       `gorm:"primaryKey;autoIncrement"`
+		Name      string     `gorm:"column:name;unique"`
+		NameEn    string     `gorm:"column:name_en"`
+		Long      float64    `gorm:"column:longitude"`
+		Lat       float64    `gorm:"column:latitude"`
+		Terminals []Terminal `gorm:"foreignKey:StationID"`
+	}
+
+	// GetAllStationQuery GetAllStationQuery
+	// Station sorted by nearest to the rider when location is given
+	GetAllStationQuery struct {
+		Lat   float64 `query:"lat" validate:"required_with=Long,omitempty,latitude"`
+		Long  float64 `query:"long" validate:"required_with=Lat,omitempty,longitude"`
+		Limit int     `query:"limit" validate:"omitempty,min=1,max=100"`
+	}
+
+	// StationStop stop of a route at the station, terminal id used for route specific api
+	// Service and bus only filled on station detail
+	StationStop struct {
+		TerminalID uint                `json:"terminalId"`
+		Route      Route               `json:"route"`
+		Long       float64             `json:"long"`
+		Lat        float64             `json:"lat"`
+		Next       string              `json:"next"`
+		Closure    *ClosureInfo        `json:"closure,omitempty"`
+		Service    *RouteServiceStatus `json:"service,omitempty"`
+		Bus        []BusInfo           `json:"bus,omitempty"`
+	}
+
+	// StationResponse StationResponse
+	StationResponse struct {
+		ID       uint          `json:"id"`
+		Name     string        `json:"name"`
+		Long     float64       `json:"long"`
+		Lat      float64       `json:"lat"`
+		Distance *float64      `json:"distance,omitempty"`
+		Places   []string      `json:"places"`
+		Stops    []StationStop `json:"stops"`
+	}
+
+	// GetAllStationResponse GetAllStationResponse
+	GetAllStationResponse struct {
+		Stations []StationResponse `json:"stations"`
+	}
+
+	// StationArrivalResponse StationArrivalResponse
+	// Bus of every route stopping at the station, sorted by estimate
+	StationArrivalResponse struct {
+		ID       uint                 `json:"id"`
+		Name     string               `json:"name"`
+		Bus      []BusInfo            `json:"bus"`
+		Service  []RouteServiceStatus `json:"service"`
+		Closures []ClosureInfo        `json:"closures"`
+	}
+)
+
+func (s *Station) Localize(locale common.Locale) {
+	s.Name = common.Localize(locale, s.Name, s.NameEn)
+	TerminalSlice(s.Terminals).Localize(locale)
+}
+
+/**
+ * Place around any stop of the station without duplicate
+ */
+func (s *Station) PlaceNames() []string {
+	var (
+		res  = make([]string, 0)
+		seen = make(map[string]bool)
+	)
+
+	for _, t := range s.Terminals {
+		for _, name := range t.PlaceNames() {
+			if !seen[name] {
+				seen[name] = true
+				res = append(res, name)
+			}
+		}
+	}
+	return res
+}
+
+func (s *Station) ToStationResponse(stops []StationStop) StationResponse {
+	return StationResponse{
+		ID:     s.ID,
+		Name:   s.Name,
+		Long:   s.Long,
+		Lat:    s.Lat,
+		Places: s.PlaceNames(),
+		Stops:  stops,
+	}
+}
+
+func (t *Terminal) ToStationStop(next string) StationStop {
+	return StationStop{
+		TerminalID: t.ID,
+		Route:      t.Route,
+		Long:       t.Long,
+		Lat:        t.Lat,
+		Next:       next,
+	}
+}
+
+/**
+ * Group terminal with the same name into station in order of first appearance
+ */
+func (t TerminalSlice) ToStations() []Station {
+	var (
+		res   = make([]Station, 0)
+		index = make(map[string]int)
+	)
+
+	for _, v := range t {
+		i, ok := index[v.Name]
+		if !ok {
+			i = len(res)
+			index[v.Name] = i
+			res = append(res, Station{Name: v.Name})
+		}
+
+		if res[i].NameEn == "" {
+			res[i].NameEn = v.NameEn
+		}
+		res[i].Terminals = append(res[i].Terminals, v)
+	}
+
+	for i := range res {
+		for _, v := range res[i].Terminals {
+			res[i].Lat += v.Lat / float64(len(res[i].Terminals))
+			res[i].Long += v.Long / float64(len(res[i].Terminals))
+		}
+	}
+
+	return res
+}
@@ -0,0 +1,97 @@
+package dto
+
+import "time"
+
+const (
+	WEBSOCKET NotificationChannel = "WEBSOCKET"
+	WEBHOOK   NotificationChannel = "WEBHOOK"
+
+	SUBSCRIPTIONDEFAULTTTL = 30 * time.Minute
+	WEBHOOKTIMEOUT         = 5 * time.Second
+)
+
+type (
+	NotificationChannel string
+
+	// ArrivalSubscription one-shot alert when a bus is within threshold minute of a terminal
+	ArrivalSubscription struct {
+		ID         uint                `gorm:"primaryKey;autoIncrement"`
+		Key        string              `gorm:"column:key;uniqueIndex"`
+		TerminalID uint                `gorm:"column:terminal_id"`
+		Route      Route               `gorm:"column:route"`
+		Threshold  int                 `gorm:"column:threshold"`
+		Channel    NotificationChannel `gorm:"column:channel"`
+		Recipient  string              `gorm:"column:recipient"`
+		ExpiresAt  time.Time           `gorm:"column:expires_at;index"`
+		NotifiedAt *time.Time          `gorm:"column:notified_at"`
+		BusID      uint                `gorm:"column:bus_id"`
+		BusNumber  int                 `gorm:"column:bus_number"`
+		BusPlate   string              `gorm:"column:bus_plate"`
+		Estimate   int                 `gorm:"column:estimate"`
+		CreatedAt  time.Time           `gorm:"column:created_at"`
+	}
+
+	ArrivalNotification struct {
+		Key        string    `json:"key"`
+		TerminalID uint      `json:"terminalId"`
+		Terminal   string    `json:"terminal"`
+		Route      Route     `json:"route"`
+		BusID      uint      `json:"busId"`
+		Number     int       `json:"number"`
+		Plate      string    `json:"plate"`
+		Estimate   int       `json:"estimate"`
+		Recipient  string    `json:"recipient,omitempty"`
+		Timestamp  time.Time `json:"timestamp"`
+	}
+
+	// CreateSubscriptionDto CreateSubscriptionDto
+	CreateSubscriptionDto struct {
+		TerminalID uint                `json:"terminalId" validate:"required"`
+		Route      Route               `json:"route" validate:"required,route"`
+		Threshold  int                 `json:"threshold" validate:"required,min=1,max=60"`
+		Channel    NotificationChannel `json:"channel" validate:"required,oneof=WEBSOCKET WEBHOOK"`
+		Recipient  string              `json:"recipient" validate:"required_if=Channel WEBHOOK"`
+		ExpiresIn  int                 `json:"expiresIn" validate:"omitempty,min=1,max=240"`
+	}
+
+	// CreateSubscriptionResponse CreateSubscriptionResponse
+	CreateSubscriptionResponse struct {
+		ID         uint                `json:"id"`
+		Key        string              `json:"key"`
+		TerminalID uint                `json:"terminalId"`
+		Route      Route               `json:"route"`
+		Threshold  int                 `json:"threshold"`
+		Channel    NotificationChannel `json:"channel"`
+		ExpiresAt  string              `json:"expiresAt"`
+	}
+)
+
+func (s *ArrivalSubscription) ToCreateSubscriptionResponse() CreateSubscriptionResponse {
+	return CreateSubscriptionResponse{
+		ID:         s.ID,
+		Key:        s.Key,
+		TerminalID: s.TerminalID,
+		Route:      s.Route,
+		Threshold:  s.Threshold,
+		Channel:    s.Channel,
+		ExpiresAt:  s.ExpiresAt.String(),
+	}
+}
+
+func (s *ArrivalSubscription) ToArrivalNotification(terminal Terminal) ArrivalNotification {
+	notification := ArrivalNotification{
+		Key:        s.Key,
+		TerminalID: terminal.ID,
+		Terminal:   terminal.Name,
+		Route:      s.Route,
+		BusID:      s.BusID,
+		Number:     s.BusNumber,
+		Plate:      s.BusPlate,
+		Estimate:   s.Estimate,
+		Recipient:  s.Recipient,
+	}
+	if s.NotifiedAt != nil {
+		notification.Timestamp = *s.NotifiedAt
+	}
+	return notification
+}
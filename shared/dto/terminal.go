@@ -1,23 +1,30 @@
 package dto
 
 import (
-	"strings"
+	"tracking-server/shared/common"
 )
 
 type (
 	Terminal struct {
-		ID          uint    `gorm:"primaryKey;autoIncrement"`
-		Name        string  `gorm:"colum:name;uniqueIndex:route_name_pair"`
-		Route       Route   `gorm:"column:route;uniqueIndex:route_name_pair"`
-		PlaceAround string  `gorm:"column:place_around"`
-		Long        float64 `gorm:"column:longitude"`
-		Lat         float64 `gorm:"column:latitude"`
+		ID        uint    `gorm:"primaryKey;autoIncrement"`
+		Name      string  `gorm:"colum:name;uniqueIndex:route_name_pair"`
+		NameEn    string  `gorm:"column:name_en"`
+		Route     Route   `gorm:"column:route;uniqueIndex:route_name_pair"`
+		Long      float64 `gorm:"column:longitude"`
+		Lat       float64 `gorm:"column:latitude"`
+		Sequence  int     `gorm:"column:sequence"`
+		StationID *uint   `gorm:"column:station_id;index"`
+		Places    []Place `gorm:"many2many:terminal_places"`
 	}
 
+	// TerminalSlice terminal of a route ordered along the route
+	TerminalSlice []Terminal
+
 	VisitedTerminal struct {
-		ID   uint   `json:"id"`
-		Name string `json:"name"`
-		Past bool   `json:"past"`
+		ID     uint   `json:"id"`
+		Name   string `json:"name"`
+		Past   bool   `json:"past"`
+		Closed bool   `json:"closed"`
 	}
 
 	// GetTerminalInfoResponse GetTerminalInfoResponse
@@ -26,14 +33,17 @@ type (
 		Route           Route             `json:"route"`
 		RelatedPlace    []string          `json:"relatedPlace"`
 		RelatedTerminal []VisitedTerminal `json:"relatedTerminal"`
+		Closure         *ClosureInfo      `json:"closure,omitempty"`
 	}
 
 	TerminalListWithDistance struct {
-		ID       uint    `json:"id"`
-		Distance float64 `json:"distance"`
-		Name     string  `json:"name"`
-		Next     string  `json:"next"`
-		Route    Route   `json:"route"`
+		ID         uint         `json:"id"`
+		Distance   float64      `json:"distance"`
+		WalkMinute int          `json:"walkMinute"`
+		Name       string       `json:"name"`
+		Next       string       `json:"next"`
+		Route      Route        `json:"route"`
+		Closure    *ClosureInfo `json:"closure,omitempty"`
 	}
 
 	// GetAllTerminalDto GetAllTerminalDto
@@ -42,13 +52,71 @@ type (
 		Lat  float64 `json:"lat" validate:"required"`
 	}
 
+	// NearestTerminalQuery NearestTerminalQuery
+	// Radius in km, terminal further than radius not included when set
+	NearestTerminalQuery struct {
+		Lat    float64 `query:"lat" validate:"required,latitude"`
+		Long   float64 `query:"long" validate:"required,longitude"`
+		Radius float64 `query:"radius" validate:"omitempty,gt=0,max=50"`
+		Route  Route   `query:"route" validate:"omitempty,route"`
+		Limit  int     `query:"limit" validate:"omitempty,min=1,max=100"`
+	}
+
 	// GetAllTerminalResponse GetAllTerminalResponse
 	GetAllTerminalResponse struct {
 		Terminals []TerminalListWithDistance `json:"terminal"`
 	}
+
+	// TerminalResponse TerminalResponse
+	TerminalResponse struct {
+		ID       uint     `json:"id"`
+		Name     string   `json:"name"`
+		NameEn   string   `json:"nameEn"`
+		Route    Route    `json:"route"`
+		Places   []string `json:"places"`
+		Long     float64  `json:"long"`
+		Lat      float64  `json:"lat"`
+		Sequence int      `json:"sequence"`
+	}
+
+	// GetRouteTerminalResponse GetRouteTerminalResponse
+	GetRouteTerminalResponse struct {
+		Route     Route              `json:"route"`
+		Terminals []TerminalResponse `json:"terminals"`
+	}
+
+	// CreateTerminalDto CreateTerminalDto
+	// Sequence left empty append the terminal at the end of the route
+	CreateTerminalDto struct {
+		Name     string  `json:"name" validate:"required"`
+		NameEn   string  `json:"nameEn" validate:"omitempty"`
+		Route    Route   `json:"route" validate:"required,route"`
+		PlaceIDs []uint  `json:"placeIds" validate:"omitempty,unique"`
+		Long     float64 `json:"long" validate:"required,longitude"`
+		Lat      float64 `json:"lat" validate:"required,latitude"`
+		Sequence int     `json:"sequence" validate:"omitempty,min=1"`
+	}
+
+	// EditTerminalDto EditTerminalDto
+	EditTerminalDto struct {
+		Name     string  `json:"name" validate:"omitempty"`
+		NameEn   string  `json:"nameEn" validate:"omitempty"`
+		PlaceIDs *[]uint `json:"placeIds" validate:"omitempty,unique"`
+		Long     float64 `json:"long" validate:"omitempty,longitude"`
+		Lat      float64 `json:"lat" validate:"omitempty,latitude"`
+	}
+
+	// ReorderTerminalDto ReorderTerminalDto
+	ReorderTerminalDto struct {
+		Route       Route  `json:"route" validate:"required,route"`
+		TerminalIDs []uint `json:"terminalIds" validate:"required,min=1,unique"`
+	}
 )
 
-func (t *Terminal) ToTerminalInfo(terminal []Terminal) GetTerminalInfoResponse {
+/**
+ * Terminal closed by an active closure marked, along with the closure reason of the terminal itself
+ */
+func (t *Terminal) ToTerminalInfo(terminal []Terminal, closures ClosureSlice) GetTerminalInfoResponse {
 	var (
 		res                      = GetTerminalInfoResponse{}
 		visitedTerminal          = make([]VisitedTerminal, 0)
@@ -56,14 +124,20 @@ func (t *Terminal) ToTerminalInfo(terminal []Terminal) GetTerminalInfoResponse {
 	)
 
 	res.Name = t.Name
-	res.RelatedPlace = strings.Split(t.PlaceAround, ",")
+	res.RelatedPlace = t.PlaceNames()
 	res.Route = t.Route
 
+	if c := closures.Find(t.ID); c != nil {
+		info := c.ToClosureInfo()
+		res.Closure = &info
+	}
+
 	for _, v := range terminal {
 		vt := VisitedTerminal{
-			ID:   v.ID,
-			Name: v.Name,
-			Past: isCurrentTerminalVisited,
+			ID:     v.ID,
+			Name:   v.Name,
+			Past:   isCurrentTerminalVisited,
+			Closed: closures.Find(v.ID) != nil,
 		}
 
 		visitedTerminal = append(visitedTerminal, vt)
@@ -78,230 +152,108 @@ func (t *Terminal) ToTerminalInfo(terminal []Terminal) GetTerminalInfoResponse {
 	return res
 }
 
-func (t *Terminal) Seeder() []Terminal {
-	return []Terminal{
-		{
-			Name:        "Asrama UI",
-			Route:       RED,
-			PlaceAround: "Wisma Makara,Hall Sabha Widya",
-			Lat:         -6.348373127525387,
-			Long:        106.8297679527903,
-		},
-		{
-			Name:        "Menwa",
-			Route:       RED,
-			PlaceAround: "Halte Transjakarta UI Depok",
-			Lat:         -6.353465386293707,
-			Long:        106.83182325822173,
-		},
-		{
-			Name:        "Stasiun UI",
-			Route:       RED,
-			PlaceAround: "Apartemen Taman Melati",
-			Lat:         -6.361046716889507,
-			Long:        106.8317240044786,
-		},
-		{
-			Name:        "FH",
-			Route:       RED,
-			PlaceAround: "Pintu Belakang Rel (Barel), Masjid UI,Perpustakaan UI,Balai Sebaguna Purnowo Prawiro UI,Fasilkom (Gedung Lama)",
-			Lat:         -6.364864762651361,
-			Long:        106.83223079221105,
-		},
-		{
-			Name:        "Balairung",
-			Route:       RED,
-			PlaceAround: "Stasiun Pondok Cina,Makara Art Center",
-			Lat:         -6.368205271318413,
-			Long:        106.83184387661237,
-		},
-		{
-			Name:        "RIK",
-			Route:       RED,
-			PlaceAround: "FKM",
-			Lat:         -6.370190241285223,
-			Long:        106.83109626794518,
-		},
-		{
-			Name:        "RSUI",
-			Route:       RED,
-			PlaceAround: "FKM",
-			Lat:         -6.371697905932189,
-			Long:        106.8293758480366,
-		},
-		{
-			Name:        "FIK",
-			Route:       RED,
-			PlaceAround: "Fasilkom (Gedung Baru)",
-			Lat:         -6.371101272862191,
-			Long:        106.82696734342873,
-		},
-		{
-			Name:        "FMIPA",
-			Route:       RED,
-			PlaceAround: "",
-			Lat:         -6.369838377677364,
-			Long:        106.82575903066468,
-		},
-		{
-			Name:        "SOR",
-			Route:       RED,
-			PlaceAround: "Politeknik Teknik Jakarta (PNJ),Gymnasium",
-			Lat:         -6.367004060974791,
-			Long:        106.82448615509534,
-		},
-		{
-			Name:        "Vokasi",
-			Route:       RED,
-			PlaceAround: "Pusat Kegiatan Mahasiswa (Pusgiwa),Stadion, Career Development UI",
-			Lat:         -6.366114158411598,
-			Long:        106.82167086626085,
-		},
-		{
-			Name:        "FT",
-			Route:       RED,
-			PlaceAround: "Pintu Kukusan-Teknik (Kutek)",
-			Lat:         -6.361069834121701,
-			Long:        106.82321257394592,
-		},
-		{
-			Name:        "FEB",
-			Route:       RED,
-			PlaceAround: "",
-			Lat:         -6.359443306471211,
-			Long:        106.82575218376806,
-		},
-		{
-			Name:        "FIB",
-			Route:       RED,
-			PlaceAround: "",
-			Lat:         -6.361133065901284,
-			Long:        106.82970210098532,
-		},
-		{
-			Name:        "FISIP",
-			Route:       RED,
-			PlaceAround: "Fasilkom (Gedung Lama)",
-			Lat:         -6.361723672481245,
-			Long:        106.83030996654941,
-		},
-		{
-			Name:        "F.Psi",
-			Route:       RED,
-			PlaceAround: "",
-			Lat:         -6.362172631787366,
-			Long:        106.83083040668357,
-		}, {
-			Name:        "Asrama UI",
-			Route:       BLUE,
-			PlaceAround: "Wisma Makara,Hall Sabha Widya",
-			Lat:         -6.348373127525387,
-			Long:        106.8297679527903,
-		},
-		{
-			Name:        "Menwa",
-			Route:       BLUE,
-			PlaceAround: "Halte Transjakarta UI Depok",
-			Lat:         -6.3534610177474,
-			Long:        106.83162029695444,
-		},
-		{
-			Name:        "Stasiun UI",
-			Route:       BLUE,
-			PlaceAround: "Apartemen Taman Melati",
-			Lat:         -6.36086929545325,
-			Long:        106.83146112622818,
-		},
-		{
-			Name:        "F.Psi",
-			Route:       BLUE,
-			PlaceAround: "",
-			Lat:         -6.362850786328479,
-			Long:        106.83116675399012,
-		},
-		{
-			Name:        "FISIP",
-			Route:       BLUE,
-			PlaceAround: "Fasilkom (Gedung Lama)",
-			Lat:         -6.361835631548166,
-			Long:        106.83016512726645,
-		},
-		{
-			Name:        "FIB",
-			Route:       BLUE,
-			PlaceAround: "",
-			Lat:         -6.361143942325545,
-			Long:        106.82947600448857,
-		},
-		{
-			Name:        "FEB",
-			Route:       BLUE,
-			PlaceAround: "",
-			Lat:         -6.359626440076783,
-			Long:        106.82572631991094,
-		},
-		{
-			Name:        "FT",
-			Route:       BLUE,
-			PlaceAround: "Pintu Kukusan-Teknik (Kutek)",
-			Lat:         -6.361277803365007,
-			Long:        106.82333110948572,
-		},
-		{
-			Name:        "Vokasi",
-			Route:       BLUE,
-			PlaceAround: "Pusat Kegiatan Mahasiswa (Pusgiwa),Stadion,Career Development UI",
-			Lat:         -6.3659382798442765,
-			Long:        106.82177091590128,
-		},
-		{
-			Name:        "SOR",
-			Route:       BLUE,
-			PlaceAround: "Politeknik Teknik Jakarta (PNJ),Gymnasium",
-			Lat:         -6.366780044175628,
-			Long:        106.82385382123188,
-		},
-		{
-			Name:        "FMIPA",
-			Route:       BLUE,
-			PlaceAround: "",
-			Lat:         -6.369756748019911,
-			Long:        106.8259792966702,
-		},
-		{
-			Name:        "FIK",
-			Route:       BLUE,
-			PlaceAround: "Fasilkom (Gedung Baru)",
-			Lat:         -6.371061340660264,
-			Long:        106.82719280923317,
-		},
-		{
-			Name:        "FKM",
-			Route:       BLUE,
-			PlaceAround: "RSUI",
-			Lat:         -6.3714849070313475,
-			Long:        106.82925853982198,
-		},
-		{
-			Name:        "RIK",
-			Route:       BLUE,
-			PlaceAround: "",
-			Lat:         -6.370075618390656,
-			Long:        106.8308615746626,
-		},
-		{
-			Name:        "Balairung",
-			Route:       BLUE,
-			PlaceAround: "Stasiun Pondok Cina,Makara Art Center",
-			Lat:         -6.36809398005471,
-			Long:        106.83161722995663,
-		},
-		{
-			Name:        "Masjid UI",
-			Route:       BLUE,
-			PlaceAround: "Pintu Belakang Rel (Barel), Masjid UI,Perpustakaan UI,Balai Sebaguna Purnowo Prawiro UI,Fasilkom (Gedung Lama)",
-			Lat:         -6.365574974922631,
-			Long:        106.83203831176702,
-		},
+func (d *GetAllTerminalDto) ToNearestTerminalQuery(limit int) NearestTerminalQuery {
+	return NearestTerminalQuery{
+		Lat:   d.Lat,
+		Long:  d.Long,
+		Limit: limit,
+	}
+}
+
+func (t *Terminal) ToTerminalResponse() TerminalResponse {
+	return TerminalResponse{
+		ID:       t.ID,
+		Name:     t.Name,
+		NameEn:   t.NameEn,
+		Route:    t.Route,
+		Places:   t.PlaceNames(),
+		Long:     t.Long,
+		Lat:      t.Lat,
+		Sequence: t.Sequence,
+	}
+}
+
+func (d *CreateTerminalDto) ToTerminal() Terminal {
+	return Terminal{
+		Name:     d.Name,
+		NameEn:   d.NameEn,
+		Route:    d.Route,
+		Long:     d.Long,
+		Lat:      d.Lat,
+		Sequence: d.Sequence,
+	}
+}
+
+func (t *Terminal) FillTerminalEdit(data EditTerminalDto) {
+	if data.Name != "" {
+		t.Name = data.Name
+	}
+
+	if data.NameEn != "" {
+		t.NameEn = data.NameEn
+	}
+
+	if data.Long != 0 {
+		t.Long = data.Long
+	}
+
+	if data.Lat != 0 {
+		t.Lat = data.Lat
+	}
+}
+
+/**
+ * Replace terminal and place name with the locale name, only for response
+ */
+func (t *Terminal) Localize(locale common.Locale) {
+	t.Name = common.Localize(locale, t.Name, t.NameEn)
+	for i := range t.Places {
+		t.Places[i].Localize(locale)
+	}
+}
+
+func (t TerminalSlice) Localize(locale common.Locale) {
+	for i := range t {
+		t[i].Localize(locale)
+	}
+}
+
+func (t *Terminal) PlaceNames() []string {
+	res := make([]string, 0, len(t.Places))
+	for _, p := range t.Places {
+		res = append(res, p.Name)
+	}
+	return res
+}
+
+func (t TerminalSlice) ToRoute() common.Route {
+	points := make([]common.Point, 0, len(t))
+	for _, v := range t {
+		points = append(points, common.Point{Lat: v.Lat, Long: v.Long})
+	}
+	return common.NewRoute(points)
+}
+
+/**
+ * Position of the terminal in the slice, -1 when not found
+ */
+func (t TerminalSlice) Index(id uint) int {
+	for i, v := range t {
+		if v.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+/**
+ * Index of the next terminal served by the route, -1 when every terminal closed
+ */
+func (t TerminalSlice) NextServed(index int, closed map[int]bool) int {
+	for n := 1; n <= len(t); n++ {
+		if i := (index + n) % len(t); !closed[i] {
+			return i
+		}
 	}
+	return -1
 }
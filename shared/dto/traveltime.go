@@ -0,0 +1,62 @@
+package dto
+
+import "time"
+
+const (
+	// Bucket with less sample than this ignored
+	TRAVELTIMEMINSAMPLE = 5
+
+	// Location gap longer than this break the trip
+	TRAVELTIMEMAXGAP = 5 * time.Minute
+
+	// Segment travel time longer than this considered outlier
+	TRAVELTIMEMAXSEGMENT = 30 * time.Minute
+
+	// Weekday bucket aggregating every day of the week
+	ALLWEEKDAY = 7
+)
+
+var (
+	// Bucket hour and weekday using local time
+	WIB = time.FixedZone("WIB", 7*60*60)
+)
+
+type (
+	// SegmentTravelTime median travel time between consecutive terminal arrival
+	SegmentTravelTime struct {
+		ID             uint      `gorm:"primaryKey;autoIncrement"`
+		Route          Route     `gorm:"column:route"`
+		FromTerminalID uint      `gorm:"column:from_terminal_id;uniqueIndex:segment_bucket"`
+		ToTerminalID   uint      `gorm:"column:to_terminal_id;uniqueIndex:segment_bucket"`
+		Weekday        int       `gorm:"column:weekday;uniqueIndex:segment_bucket"`
+		Hour           int       `gorm:"column:hour;uniqueIndex:segment_bucket"`
+		Samples        int       `gorm:"column:samples"`
+		Seconds        float64   `gorm:"column:seconds"`
+		UpdatedAt      time.Time `gorm:"column:updated_at"`
+	}
+
+	SegmentBucket struct {
+		FromTerminalID uint
+		ToTerminalID   uint
+		Weekday        int
+		Hour           int
+	}
+)
+
+func NewSegmentBucket(from uint, to uint, weekday int, at time.Time) SegmentBucket {
+	return SegmentBucket{
+		FromTerminalID: from,
+		ToTerminalID:   to,
+		Weekday:        weekday,
+		Hour:           at.In(WIB).Hour(),
+	}
+}
+
+func (s *SegmentTravelTime) Bucket() SegmentBucket {
+	return SegmentBucket{
+		FromTerminalID: s.FromTerminalID,
+		ToTerminalID:   s.ToTerminalID,
+		Weekday:        s.Weekday,
+		Hour:           s.Hour,
+	}
+}
@@ -0,0 +1,76 @@
+package dto
+
+const (
+	// Walking speed in m/s
+	WALKINGSPEED = 1.3
+
+	// Terminal within this distance in km from origin considered as boarding terminal
+	TRIPMAXWALK = 1.5
+
+	TRIPMAXOPTION = 5
+)
+
+type (
+	// PlanTripDto PlanTripDto
+	PlanTripDto struct {
+		Long        float64 `json:"long" validate:"required"`
+		Lat         float64 `json:"lat" validate:"required"`
+		TerminalID  uint    `json:"terminalId" validate:"required_without=Destination"`
+		Destination string  `json:"destination" validate:"required_without=TerminalID"`
+	}
+
+	TripTerminal struct {
+		ID   uint    `json:"id"`
+		Name string  `json:"name"`
+		Long float64 `json:"long"`
+		Lat  float64 `json:"lat"`
+	}
+
+	TripBus struct {
+		ID     uint   `json:"id"`
+		Number int    `json:"number"`
+		Plate  string `json:"plate"`
+	}
+
+	TripOption struct {
+		Route        Route        `json:"route"`
+		Board        TripTerminal `json:"board"`
+		Alight       TripTerminal `json:"alight"`
+		Bus          TripBus      `json:"bus"`
+		WalkDistance float64      `json:"walkDistance"`
+		WalkMinute   int          `json:"walkMinute"`
+		WaitMinute   int          `json:"waitMinute"`
+		RideMinute   int          `json:"rideMinute"`
+		Stops        int          `json:"stops"`
+		TotalMinute  int          `json:"totalMinute"`
+	}
+
+	// PlanTripResponse PlanTripResponse
+	PlanTripResponse struct {
+		Options []TripOption `json:"options"`
+	}
+)
+
+/**
+ * Walking time in minute of a distance in km, rounded to the nearest minute
+ */
+func WalkMinute(distance float64) int {
+	return int(distance*1000/WALKINGSPEED/60 + 0.5)
+}
+
+func (t *Terminal) ToTripTerminal() TripTerminal {
+	return TripTerminal{
+		ID:   t.ID,
+		Name: t.Name,
+		Long: t.Long,
+		Lat:  t.Lat,
+	}
+}
+
+func (b *BusInfo) ToTripBus() TripBus {
+	return TripBus{
+		ID:     b.ID,
+		Number: b.Number,
+		Plate:  b.Plate,
+	}
+}